@@ -1,24 +1,136 @@
 package main
 
 import (
+	"encoding"
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/server"
 )
 
-// GetUint16 retrieves a uint16 value from the environment or returns the default.
-func GetUint16(key string, defaultVal uint16) uint16 {
-	if val, ok := os.LookupEnv(key); ok {
-		if port, err := strconv.ParseUint(val, 10, 16); err == nil && port <= 65535 {
-			return uint16(port)
+// applyEnv overrides cfg with any ODY_* environment variables that are set.
+// Every variable that cannot be parsed is reported rather than ignored.
+func applyEnv(cfg *server.Config) error {
+	e := &env{}
+	e.string("ODY_SERVER_NAME", &cfg.Name)
+	e.int("ODY_CAPACITY", &cfg.Capacity)
+	e.string("ODY_REGISTRY", &cfg.Registry)
+
+	// The *_PORT variables predate bind addresses and listen on every
+	// interface; *_ADDR wins when both are set.
+	e.port("ODY_ADMIN_PORT", &cfg.Listen.Admin)
+	e.port("ODY_META_PORT", &cfg.Listen.Meta)
+	e.port("ODY_NETWORK_PORT", &cfg.Listen.Network)
+	e.string("ODY_ADMIN_ADDR", &cfg.Listen.Admin)
+	e.string("ODY_META_ADDR", &cfg.Listen.Meta)
+	e.string("ODY_NETWORK_ADDR", &cfg.Listen.Network)
+
+	e.string("ODY_DATA_DIR", &cfg.DataDir)
+	e.text("ODY_LOG_LEVEL", &cfg.LogLevel)
+	e.duration("ODY_SHUTDOWN_TIMEOUT", &cfg.ShutdownTimeout)
+
+	e.uint16("ODY_TICK_RATE", &cfg.Game.TickRate)
+	e.int("ODY_SPAWN_MAP", &cfg.Game.Spawn.MapID)
+	e.int("ODY_SPAWN_X", &cfg.Game.Spawn.X)
+	e.int("ODY_SPAWN_Y", &cfg.Game.Spawn.Y)
+	e.bool("ODY_ADJACENT_MAPS", &cfg.Game.AdjacentMaps)
+	e.text("ODY_DUPLICATE_LOGIN", &cfg.Game.DuplicateLogin)
+
+	e.string("ODY_AUTH_SECRET", &cfg.Auth.Secret)
+	e.string("ODY_AUTH_PUBLIC_KEY", &cfg.Auth.PublicKeyFile)
+	e.string("ODY_AUTH_AUDIENCE", &cfg.Auth.Audience)
+	e.duration("ODY_AUTH_TOKEN_TTL", &cfg.Auth.TokenTTL)
+	return e.err()
+}
+
+// env reads typed values from the environment, collecting parse errors.
+type env struct {
+	errs []error
+}
+
+// lookup returns the variable's value, treating an empty value as unset.
+func (e *env) lookup(key string) (string, bool) {
+	val, ok := os.LookupEnv(key)
+	return val, ok && val != ""
+}
+
+func (e *env) invalid(key, val, want string) {
+	e.errs = append(e.errs, fmt.Errorf("%s=%q: want %s", key, val, want))
+}
+
+func (e *env) err() error {
+	return errors.Join(e.errs...)
+}
+
+func (e *env) string(key string, dst *string) {
+	if val, ok := e.lookup(key); ok {
+		*dst = val
+	}
+}
+
+func (e *env) int(key string, dst *int) {
+	if val, ok := e.lookup(key); ok {
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			e.invalid(key, val, "an integer")
+			return
 		}
+		*dst = n
 	}
-	return defaultVal
 }
 
-// GetString retrieves a string value from the environment or returns the default.
-func GetString(key, defaultVal string) string {
-	if val, ok := os.LookupEnv(key); ok && val != "" {
-		return val
+func (e *env) uint16(key string, dst *uint16) {
+	if val, ok := e.lookup(key); ok {
+		n, err := strconv.ParseUint(val, 10, 16)
+		if err != nil {
+			e.invalid(key, val, "an integer from 0 to 65535")
+			return
+		}
+		*dst = uint16(n)
+	}
+}
+
+// port sets dst to listen on the given port on every interface.
+func (e *env) port(key string, dst *string) {
+	if val, ok := e.lookup(key); ok {
+		if _, err := strconv.ParseUint(val, 10, 16); err != nil {
+			e.invalid(key, val, "a port from 0 to 65535")
+			return
+		}
+		*dst = ":" + val
+	}
+}
+
+func (e *env) bool(key string, dst *bool) {
+	if val, ok := e.lookup(key); ok {
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			e.invalid(key, val, "true or false")
+			return
+		}
+		*dst = b
+	}
+}
+
+func (e *env) duration(key string, dst *time.Duration) {
+	if val, ok := e.lookup(key); ok {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			e.invalid(key, val, `a duration such as "15s"`)
+			return
+		}
+		*dst = d
+	}
+}
+
+// text parses values such as log levels that know how to read themselves.
+func (e *env) text(key string, dst encoding.TextUnmarshaler) {
+	if val, ok := e.lookup(key); ok {
+		if err := dst.UnmarshalText([]byte(val)); err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s=%q: %w", key, val, err))
+		}
 	}
-	return defaultVal
 }
@@ -3,45 +3,103 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"sync"
+	"syscall"
 
 	"github.com/Odyssey-Classic/server/internal/server"
 
 	"github.com/Odyssey-Classic/server/pb"
 )
 
+// version is the build version reported to the registry. Release builds set
+// it with -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	// TODO: we don't ned this here, but not sure where we need it yet.
 	var _ pb.GameMessage
 
-	var registryURL string
-	flag.StringVar(&registryURL, "registry", "http://local.fosteredgames.com:8080", "Registry URL")
+	var (
+		configPath  string
+		registryURL string
+		dataDir     string
+		logLevel    string
+		adminAddr   string
+		metaAddr    string
+		networkAddr string
+	)
+	flag.StringVar(&configPath, "config", os.Getenv("ODY_CONFIG"), "YAML config file; defaults to $ODY_CONFIG")
+	flag.StringVar(&registryURL, "registry", "", "Registry URL to list the server with")
+	flag.StringVar(&dataDir, "data-dir", "", "Data directory")
+	flag.StringVar(&logLevel, "log-level", "", "Log level: debug, info, warn or error")
+	flag.StringVar(&adminAddr, "admin-addr", "", "Admin API listen address, such as :8081")
+	flag.StringVar(&metaAddr, "meta-addr", "", "Meta API listen address, such as :8082")
+	flag.StringVar(&networkAddr, "network-addr", "", "Game network listen address, such as :8080")
 	flag.Parse()
 
-	cfg := server.Config{
-		Ports: server.Ports{
-			Admin:   GetUint16("ODY_ADMIN_PORT", 8081),
-			Meta:    GetUint16("ODY_META_PORT", 8082),
-			Network: GetUint16("ODY_NETWORK_PORT", 8080),
-		},
-		DataDir: GetString("ODY_DATA_DIR", "data"),
+	// Settings are layered: defaults, then the config file, then the
+	// environment, then flags.
+	cfg := server.DefaultConfig()
+	if configPath != "" {
+		if err := server.LoadConfigFile(configPath, &cfg); err != nil {
+			fail(err)
+		}
+	}
+	if err := applyEnv(&cfg); err != nil {
+		fail(err)
 	}
+	var flagErr error
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "registry":
+			cfg.Registry = registryURL
+		case "data-dir":
+			cfg.DataDir = dataDir
+		case "log-level":
+			if err := cfg.LogLevel.UnmarshalText([]byte(logLevel)); err != nil {
+				flagErr = fmt.Errorf("-log-level: %w", err)
+			}
+		case "admin-addr":
+			cfg.Listen.Admin = adminAddr
+		case "meta-addr":
+			cfg.Listen.Meta = metaAddr
+		case "network-addr":
+			cfg.Listen.Network = networkAddr
+		}
+	})
+	if flagErr != nil {
+		fail(flagErr)
+	}
+	cfg.Version = version
+	slog.SetLogLoggerLevel(cfg.LogLevel)
 
-	srv, err := server.NewServer(cfg,
-		server.WithRegistry(registryURL),
-	)
+	var options []server.Option
+	if cfg.Registry != "" {
+		options = append(options, server.WithRegistry(cfg.Registry))
+	}
+
+	srv, err := server.NewServer(cfg, options...)
 	if err != nil {
-		slog.Error(err.Error())
-		os.Exit(1)
+		fail(err)
 	}
 
 	wg := new(sync.WaitGroup)
-	srv.Start(ctx, wg)
+	if err := srv.Start(ctx, wg); err != nil {
+		slog.Error("server failed to start", "error", err)
+		os.Exit(1)
+	}
 	wg.Wait()
 }
+
+// fail reports an invalid configuration and exits.
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+	os.Exit(2)
+}
@@ -2,14 +2,20 @@ module github.com/Odyssey-Classic/server
 
 go 1.22.2
 
-require github.com/gorilla/websocket v1.5.3
+require (
+	github.com/go-chi/chi/v5 v5.2.1
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/gorilla/websocket v1.5.3
+	github.com/stretchr/testify v1.10.0
+	go.starlark.net v0.0.0-20250623223156-8bf495bf4e9a
+	golang.org/x/crypto v0.33.0
+	golang.org/x/sync v0.8.0
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/go-chi/chi/v5 v5.2.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
-	golang.org/x/sync v0.8.0 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.30.0 // indirect
 )
@@ -0,0 +1,22 @@
+// Package auth provides the bearer tokens that identify players to the
+// server's realtime Network service, and the username, password and ID rules
+// shared by player accounts and administrators.
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the JWT claims carried by a player's bearer token.
+// The account ID is stored in the standard "sub" claim.
+type Claims struct {
+	jwt.RegisteredClaims
+	CharacterID string `json:"character_id,omitempty"`
+	// CharacterName is the display name of CharacterID.
+	CharacterName string `json:"character_name,omitempty"`
+}
+
+// AccountID returns the ID of the account the token was issued to.
+func (c *Claims) AccountID() string {
+	return c.Subject
+}
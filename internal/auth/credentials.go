@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password length limits. bcrypt ignores everything past 72 bytes, so longer
+// passwords are refused rather than silently truncated.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+var (
+	// ErrInvalidUsername is returned for usernames that fail validation.
+	ErrInvalidUsername = errors.New("username must be 3-20 letters, digits or underscores")
+	// ErrInvalidPassword is returned for passwords that fail validation.
+	ErrInvalidPassword = fmt.Errorf("password must be %d-%d bytes", MinPasswordLength, MaxPasswordLength)
+	// ErrWrongPassword is returned when a password does not match its hash.
+	ErrWrongPassword = errors.New("wrong password")
+)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{3,20}$`)
+	idPattern       = regexp.MustCompile(`^[0-9a-f]{32}$`)
+)
+
+// ValidateUsername checks username against the naming rules shared by player
+// accounts and administrators.
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return ErrInvalidUsername
+	}
+	return nil
+}
+
+// ValidatePassword checks password against the length limits.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// HashPassword returns the bcrypt hash of password.
+func HashPassword(password string) ([]byte, error) {
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+// CheckPassword reports whether password matches hash.
+func CheckPassword(hash []byte, password string) error {
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		return ErrWrongPassword
+	}
+	return nil
+}
+
+// ValidID reports whether id has the form NewID produces. Stores check IDs
+// before using them in file names.
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// NewID returns a random identifier suitable for accounts, characters and
+// administrators.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
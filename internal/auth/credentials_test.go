@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CredentialsSuite struct {
+	suite.Suite
+}
+
+func (s *CredentialsSuite) TestUsername() {
+	s.NoError(ValidateUsername("Hero_42"))
+	s.ErrorIs(ValidateUsername("no"), ErrInvalidUsername)
+	s.ErrorIs(ValidateUsername("bad name"), ErrInvalidUsername)
+}
+
+func (s *CredentialsSuite) TestPassword() {
+	s.ErrorIs(ValidatePassword("short"), ErrInvalidPassword)
+	s.ErrorIs(ValidatePassword(strings.Repeat("x", MaxPasswordLength+1)), ErrInvalidPassword)
+
+	hash, err := HashPassword("correct horse")
+	s.Require().NoError(err)
+	s.NoError(CheckPassword(hash, "correct horse"))
+	s.ErrorIs(CheckPassword(hash, "wrong horse"), ErrWrongPassword)
+}
+
+func (s *CredentialsSuite) TestID() {
+	id, err := NewID()
+	s.Require().NoError(err)
+	s.True(ValidID(id))
+	s.False(ValidID("../" + id))
+}
+
+func TestCredentialsSuite(t *testing.T) {
+	suite.Run(t, new(CredentialsSuite))
+}
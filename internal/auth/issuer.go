@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultTokenTTL is how long issued tokens stay valid unless configured
+// otherwise.
+const DefaultTokenTTL = 24 * time.Hour
+
+// Issuer signs bearer tokens with a shared secret using HS256. Its tokens are
+// accepted by a Verifier from NewHMACVerifier with the same secret and
+// audience.
+type Issuer struct {
+	secret   []byte
+	audience string
+	ttl      time.Duration
+}
+
+// NewHMACIssuer returns an Issuer whose tokens are valid for ttl. When
+// audience is non-empty it is added to every token's "aud" claim.
+func NewHMACIssuer(secret []byte, audience string, ttl time.Duration) *Issuer {
+	return &Issuer{secret: secret, audience: audience, ttl: ttl}
+}
+
+// Issue signs a token for the account. Tokens that should let a client play
+// also name the character; an account only token may be used with Meta but is
+// refused by the game.
+func (i *Issuer) Issue(accountID, characterID, characterName string) (string, time.Time, error) {
+	now := time.Now()
+	expires := now.Add(i.ttl)
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   accountID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expires),
+		},
+		CharacterID:   characterID,
+		CharacterName: characterName,
+	}
+	if i.audience != "" {
+		claims.Audience = jwt.ClaimStrings{i.audience}
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expires, nil
+}
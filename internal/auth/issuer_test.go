@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IssuerSuite struct {
+	suite.Suite
+	secret []byte
+}
+
+func (s *IssuerSuite) SetupTest() {
+	s.secret = []byte("test-secret")
+}
+
+func (s *IssuerSuite) TestRoundTrip() {
+	issuer := NewHMACIssuer(s.secret, "odyssey", time.Hour)
+	token, expires, err := issuer.Issue("account-1", "char-1", "Hero")
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now().Add(time.Hour), expires, time.Minute)
+
+	claims, err := NewHMACVerifier(s.secret, "odyssey").Verify(token)
+	s.Require().NoError(err)
+	s.Equal("account-1", claims.AccountID())
+	s.Equal("char-1", claims.CharacterID)
+	s.Equal("Hero", claims.CharacterName)
+}
+
+func (s *IssuerSuite) TestAudienceMismatch() {
+	token, _, err := NewHMACIssuer(s.secret, "", time.Hour).Issue("account-1", "", "")
+	s.Require().NoError(err)
+
+	_, err = NewHMACVerifier(s.secret, "odyssey").Verify(token)
+	s.Error(err)
+}
+
+func (s *IssuerSuite) TestExpired() {
+	token, _, err := NewHMACIssuer(s.secret, "", -time.Hour).Issue("account-1", "", "")
+	s.Require().NoError(err)
+
+	_, err = NewHMACVerifier(s.secret, "").Verify(token)
+	s.Error(err)
+}
+
+func TestIssuer(t *testing.T) {
+	suite.Run(t, new(IssuerSuite))
+}
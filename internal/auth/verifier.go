@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// leeway is the clock skew tolerated when checking time based claims.
+const leeway = 30 * time.Second
+
+// ErrMissingSubject is returned for tokens that do not identify an account.
+var ErrMissingSubject = errors.New("token has no subject")
+
+// Verifier validates a bearer token and returns its claims.
+type Verifier interface {
+	Verify(token string) (*Claims, error)
+}
+
+// tokenVerifier is a Verifier for a single signing key.
+type tokenVerifier struct {
+	key    any
+	parser *jwt.Parser
+}
+
+// NewHMACVerifier returns a Verifier for tokens signed with the shared secret
+// using HS256, HS384 or HS512. When audience is non-empty tokens must list it
+// in their "aud" claim.
+func NewHMACVerifier(secret []byte, audience string) Verifier {
+	return newTokenVerifier(secret, []string{"HS256", "HS384", "HS512"}, audience)
+}
+
+// NewPublicKeyVerifier returns a Verifier for tokens signed by the private half
+// of the PEM encoded public key stored at path. RSA, ECDSA and Ed25519 keys
+// are supported. When audience is non-empty tokens must list it in their
+// "aud" claim.
+func NewPublicKeyVerifier(path, audience string) (Verifier, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("public key %s: no PEM data found", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("public key %s: %w", path, err)
+	}
+
+	var methods []string
+	switch key.(type) {
+	case *rsa.PublicKey:
+		methods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+	case *ecdsa.PublicKey:
+		methods = []string{"ES256", "ES384", "ES512"}
+	case ed25519.PublicKey:
+		methods = []string{"EdDSA"}
+	default:
+		return nil, fmt.Errorf("public key %s: unsupported key type %T", path, key)
+	}
+	return newTokenVerifier(key, methods, audience), nil
+}
+
+func newTokenVerifier(key any, methods []string, audience string) *tokenVerifier {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	return &tokenVerifier{
+		key:    key,
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+// Verify checks the token's signature, expiry and audience.
+func (v *tokenVerifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return v.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, ErrMissingSubject
+	}
+	return claims, nil
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifierSuite struct {
+	suite.Suite
+	secret []byte
+}
+
+func (s *VerifierSuite) SetupTest() {
+	s.secret = []byte("test-secret")
+}
+
+func (s *VerifierSuite) claims() *Claims {
+	now := time.Now()
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "account-1",
+			Audience:  jwt.ClaimStrings{"odyssey"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		CharacterID: "character-1",
+	}
+}
+
+func (s *VerifierSuite) sign(method jwt.SigningMethod, key any, claims *Claims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	s.Require().NoError(err)
+	return token
+}
+
+// writePublicKey stores pub as a PEM file and returns its path.
+func (s *VerifierSuite) writePublicKey(pub any) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	s.Require().NoError(err)
+	path := filepath.Join(s.T().TempDir(), "key.pem")
+	s.Require().NoError(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func (s *VerifierSuite) TestHMACValid() {
+	v := NewHMACVerifier(s.secret, "odyssey")
+
+	claims, err := v.Verify(s.sign(jwt.SigningMethodHS256, s.secret, s.claims()))
+	s.Require().NoError(err)
+	s.Equal("account-1", claims.AccountID())
+	s.Equal("character-1", claims.CharacterID)
+}
+
+func (s *VerifierSuite) TestHMACWrongSecret() {
+	v := NewHMACVerifier(s.secret, "odyssey")
+
+	_, err := v.Verify(s.sign(jwt.SigningMethodHS256, []byte("other"), s.claims()))
+	s.ErrorIs(err, jwt.ErrTokenSignatureInvalid)
+}
+
+func (s *VerifierSuite) TestExpired() {
+	v := NewHMACVerifier(s.secret, "odyssey")
+	c := s.claims()
+	c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	_, err := v.Verify(s.sign(jwt.SigningMethodHS256, s.secret, c))
+	s.ErrorIs(err, jwt.ErrTokenExpired)
+}
+
+func (s *VerifierSuite) TestMissingExpiry() {
+	v := NewHMACVerifier(s.secret, "odyssey")
+	c := s.claims()
+	c.ExpiresAt = nil
+
+	_, err := v.Verify(s.sign(jwt.SigningMethodHS256, s.secret, c))
+	s.ErrorIs(err, jwt.ErrTokenRequiredClaimMissing)
+}
+
+func (s *VerifierSuite) TestWrongAudience() {
+	v := NewHMACVerifier(s.secret, "somewhere-else")
+
+	_, err := v.Verify(s.sign(jwt.SigningMethodHS256, s.secret, s.claims()))
+	s.ErrorIs(err, jwt.ErrTokenInvalidAudience)
+}
+
+func (s *VerifierSuite) TestMissingSubject() {
+	v := NewHMACVerifier(s.secret, "odyssey")
+	c := s.claims()
+	c.Subject = ""
+
+	_, err := v.Verify(s.sign(jwt.SigningMethodHS256, s.secret, c))
+	s.ErrorIs(err, ErrMissingSubject)
+}
+
+func (s *VerifierSuite) TestUnsignedRejected() {
+	v := NewHMACVerifier(s.secret, "odyssey")
+
+	_, err := v.Verify(s.sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, s.claims()))
+	s.Error(err)
+}
+
+func (s *VerifierSuite) TestECDSAPublicKey() {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	v, err := NewPublicKeyVerifier(s.writePublicKey(&priv.PublicKey), "odyssey")
+	s.Require().NoError(err)
+
+	claims, err := v.Verify(s.sign(jwt.SigningMethodES256, priv, s.claims()))
+	s.Require().NoError(err)
+	s.Equal("account-1", claims.AccountID())
+
+	// A token signed with the HMAC secret must not be accepted by a key verifier.
+	_, err = v.Verify(s.sign(jwt.SigningMethodHS256, s.secret, s.claims()))
+	s.Error(err)
+}
+
+func (s *VerifierSuite) TestEd25519PublicKey() {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+	v, err := NewPublicKeyVerifier(s.writePublicKey(pub), "")
+	s.Require().NoError(err)
+
+	_, err = v.Verify(s.sign(jwt.SigningMethodEdDSA, priv, s.claims()))
+	s.NoError(err)
+}
+
+func (s *VerifierSuite) TestPublicKeyFileErrors() {
+	_, err := NewPublicKeyVerifier(filepath.Join(s.T().TempDir(), "missing.pem"), "")
+	s.Error(err)
+
+	path := filepath.Join(s.T().TempDir(), "garbage.pem")
+	s.Require().NoError(os.WriteFile(path, []byte("not a key"), 0o600))
+	_, err = NewPublicKeyVerifier(path, "")
+	s.Error(err)
+}
+
+func TestVerifier(t *testing.T) {
+	suite.Run(t, new(VerifierSuite))
+}
@@ -9,6 +9,21 @@ import (
 type Root interface {
 	// MapsDir returns the path to the maps data directory
 	MapsDir() string
+
+	// ScriptsDir returns the path to the tile trigger scripts directory
+	ScriptsDir() string
+
+	// AccountsDir returns the path to the player accounts directory
+	AccountsDir() string
+
+	// CharactersDir returns the path to the player characters directory
+	CharactersDir() string
+
+	// AdminsDir returns the path to the administrator accounts directory
+	AdminsDir() string
+
+	// AuditDir returns the path to the admin audit log directory
+	AuditDir() string
 }
 
 // osRoot is an implementation of Root that uses the operating system's filesystem
@@ -28,3 +43,28 @@ func NewOSRoot(baseDir string) Root {
 func (r *osRoot) MapsDir() string {
 	return filepath.Join(r.baseDir, "maps")
 }
+
+// ScriptsDir returns the path to the scripts subdirectory within the base data directory
+func (r *osRoot) ScriptsDir() string {
+	return filepath.Join(r.baseDir, "scripts")
+}
+
+// AccountsDir returns the path to the accounts subdirectory within the base data directory
+func (r *osRoot) AccountsDir() string {
+	return filepath.Join(r.baseDir, "accounts")
+}
+
+// CharactersDir returns the path to the characters subdirectory within the base data directory
+func (r *osRoot) CharactersDir() string {
+	return filepath.Join(r.baseDir, "characters")
+}
+
+// AdminsDir returns the path to the admins subdirectory within the base data directory
+func (r *osRoot) AdminsDir() string {
+	return filepath.Join(r.baseDir, "admins")
+}
+
+// AuditDir returns the path to the audit subdirectory within the base data directory
+func (r *osRoot) AuditDir() string {
+	return filepath.Join(r.baseDir, "audit")
+}
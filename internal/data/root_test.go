@@ -41,3 +41,37 @@ func (s *RootTestSuite) TestMapsDir_RelativePath() {
 
 	s.Equal(expected, mapsDir, "MapsDir should work with relative paths")
 }
+
+func (s *RootTestSuite) TestScriptsDir() {
+	baseDir := "/test/data"
+	root := NewOSRoot(baseDir)
+
+	scriptsDir := root.ScriptsDir()
+	expected := filepath.Join(baseDir, "scripts")
+
+	s.Equal(expected, scriptsDir, "ScriptsDir should return the correct path")
+}
+
+func (s *RootTestSuite) TestAccountsDir() {
+	root := NewOSRoot("/test/data")
+
+	s.Equal(filepath.Join("/test/data", "accounts"), root.AccountsDir(), "AccountsDir should return the correct path")
+}
+
+func (s *RootTestSuite) TestCharactersDir() {
+	root := NewOSRoot("/test/data")
+
+	s.Equal(filepath.Join("/test/data", "characters"), root.CharactersDir(), "CharactersDir should return the correct path")
+}
+
+func (s *RootTestSuite) TestAdminsDir() {
+	root := NewOSRoot("/test/data")
+
+	s.Equal(filepath.Join("/test/data", "admins"), root.AdminsDir(), "AdminsDir should return the correct path")
+}
+
+func (s *RootTestSuite) TestAuditDir() {
+	root := NewOSRoot("/test/data")
+
+	s.Equal(filepath.Join("/test/data", "audit"), root.AuditDir(), "AuditDir should return the correct path")
+}
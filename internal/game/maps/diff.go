@@ -0,0 +1,177 @@
+package maps
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// Diff lists what differs between two versions of a map.
+type Diff struct {
+	FromVersion int `json:"from_version"`
+	ToVersion   int `json:"to_version"`
+	// Fields names other changed map properties, such as "name" and "tags".
+	Fields     []string          `json:"fields"`
+	Attributes []AttributeChange `json:"attributes"`
+	Links      []LinkChange      `json:"links"`
+	Tiles      []TileChange      `json:"tiles"`
+}
+
+// AttributeChange is an attribute's value before and after. A nil value
+// means the attribute was not set.
+type AttributeChange struct {
+	Key  string  `json:"key"`
+	From *string `json:"from"`
+	To   *string `json:"to"`
+}
+
+// LinkChange is the map linked in a direction before and after, 0 for none.
+type LinkChange struct {
+	Direction Direction `json:"direction"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+}
+
+// GraphicChange is the graphic on a layer before and after. A nil graphic
+// means the layer was empty.
+type GraphicChange struct {
+	Layer int      `json:"layer"`
+	From  *Graphic `json:"from"`
+	To    *Graphic `json:"to"`
+}
+
+// TileChange describes how one tile differs.
+type TileChange struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	// Fields names other changed tile properties: "passable",
+	// "blocked_directions", "warp" or "trigger".
+	Fields     []string          `json:"fields,omitempty"`
+	Graphics   []GraphicChange   `json:"graphics,omitempty"`
+	Attributes []AttributeChange `json:"attributes,omitempty"`
+}
+
+// Compare returns the differences from one version of a map to another.
+// Bookkeeping fields, the ID, version and update time, are not compared.
+func Compare(from, to *Map) Diff {
+	d := Diff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Fields:      []string{},
+		Attributes:  compareAttributes(from.Attributes, to.Attributes),
+		Links:       []LinkChange{},
+		Tiles:       []TileChange{},
+	}
+	if from.Name != to.Name {
+		d.Fields = append(d.Fields, "name")
+	}
+	if !slices.Equal(from.Tags, to.Tags) {
+		d.Fields = append(d.Fields, "tags")
+	}
+	for dir := North; dir <= West; dir++ {
+		if a, b := from.Links.Get(dir), to.Links.Get(dir); a != b {
+			d.Links = append(d.Links, LinkChange{Direction: dir, From: a, To: b})
+		}
+	}
+	for x := 0; x < Size; x++ {
+		for y := 0; y < Size; y++ {
+			if c, changed := compareTiles(x, y, &from.Tiles[x][y], &to.Tiles[x][y]); changed {
+				d.Tiles = append(d.Tiles, c)
+			}
+		}
+	}
+	return d
+}
+
+func compareTiles(x, y int, from, to *Tile) (TileChange, bool) {
+	c := TileChange{X: x, Y: y}
+	if from.Passable != to.Passable {
+		c.Fields = append(c.Fields, "passable")
+	}
+	if !reflect.DeepEqual(nonEmpty(from.BlockedDirections), nonEmpty(to.BlockedDirections)) {
+		c.Fields = append(c.Fields, "blocked_directions")
+	}
+	if !reflect.DeepEqual(from.Warp, to.Warp) {
+		c.Fields = append(c.Fields, "warp")
+	}
+	if from.Trigger != to.Trigger {
+		c.Fields = append(c.Fields, "trigger")
+	}
+	c.Graphics = compareGraphics(from.Graphics, to.Graphics)
+	if attrs := compareAttributes(from.Attributes, to.Attributes); len(attrs) > 0 {
+		c.Attributes = attrs
+	}
+	changed := len(c.Fields) > 0 || len(c.Graphics) > 0 || len(c.Attributes) > 0
+	return c, changed
+}
+
+// nonEmpty treats empty and nil slices alike.
+func nonEmpty(blocks []DirectionalBlock) []DirectionalBlock {
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks
+}
+
+func compareGraphics(from, to map[int]Graphic) []GraphicChange {
+	var changes []GraphicChange
+	for _, layer := range sortedKeys(from, to) {
+		a, inFrom := from[layer]
+		b, inTo := to[layer]
+		if inFrom == inTo && reflect.DeepEqual(nonEmptyProps(a), nonEmptyProps(b)) {
+			continue
+		}
+		c := GraphicChange{Layer: layer}
+		if inFrom {
+			c.From = &a
+		}
+		if inTo {
+			c.To = &b
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// nonEmptyProps treats empty and nil properties alike.
+func nonEmptyProps(g Graphic) Graphic {
+	if len(g.Properties) == 0 {
+		g.Properties = nil
+	}
+	return g
+}
+
+func compareAttributes(from, to map[string]string) []AttributeChange {
+	changes := []AttributeChange{}
+	for _, key := range sortedKeys(from, to) {
+		a, inFrom := from[key]
+		b, inTo := to[key]
+		if inFrom == inTo && a == b {
+			continue
+		}
+		c := AttributeChange{Key: key}
+		if inFrom {
+			c.From = &a
+		}
+		if inTo {
+			c.To = &b
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// sortedKeys returns the keys found in either map, in order.
+func sortedKeys[K int | string, V any](a, b map[K]V) []K {
+	keys := make([]K, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
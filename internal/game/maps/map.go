@@ -6,6 +6,9 @@ import (
 	"time"
 )
 
+// Size is the width and height, in tiles, of every map.
+const Size = 17
+
 // Map represents a game map with a 17x17 grid of tiles.
 type Map struct {
 	ID          int               `json:"id"`
@@ -33,7 +36,7 @@ func (m Map) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON customizes deserialization of Map to parse LastUpdated from ISO8601.
 func (m *Map) UnmarshalJSON(data []byte) error {
 	type Alias Map
-	aux := &struct {
+	aux := struct {
 		LastUpdated string `json:"last_updated"`
 		*Alias
 	}{
@@ -42,6 +45,11 @@ func (m *Map) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
+	// Maps sent for creation need not carry a timestamp; the store sets it.
+	if aux.LastUpdated == "" {
+		m.LastUpdated = time.Time{}
+		return nil
+	}
 	parsed, err := time.Parse(time.RFC3339, aux.LastUpdated)
 	if err != nil {
 		return err
@@ -52,7 +60,7 @@ func (m *Map) UnmarshalJSON(data []byte) error {
 
 // GetTile returns the tile at the given x, y coordinates.
 func (m *Map) GetTile(x, y int) (*Tile, error) {
-	if x < 0 || x >= 17 || y < 0 || y >= 17 {
+	if !InBounds(x, y) {
 		return nil, fmt.Errorf("coordinates out of range: (%d, %d)", x, y)
 	}
 	return &m.Tiles[x][y], nil
@@ -60,7 +68,7 @@ func (m *Map) GetTile(x, y int) (*Tile, error) {
 
 // SetTile updates the tile at the given x, y coordinates.
 func (m *Map) SetTile(x, y int, tile Tile) error {
-	if x < 0 || x >= 17 || y < 0 || y >= 17 {
+	if !InBounds(x, y) {
 		return fmt.Errorf("coordinates out of range: (%d, %d)", x, y)
 	}
 	m.Tiles[x][y] = tile
@@ -69,25 +77,20 @@ func (m *Map) SetTile(x, y int, tile Tile) error {
 	return nil
 }
 
-// IsPassable checks if a tile is passable and not blocked in a specific direction.
+// InBounds reports whether x, y lies within a map.
+func InBounds(x, y int) bool {
+	return x >= 0 && x < Size && y >= 0 && y < Size
+}
+
+// IsPassable checks if a tile can be entered through side fromDirection. It
+// follows the same rule as Tile.CanEnter: only inbound blocks on that side
+// stop entry.
 func (m *Map) IsPassable(x, y int, fromDirection Direction) (bool, error) {
 	tile, err := m.GetTile(x, y)
 	if err != nil {
 		return false, err
 	}
-
-	if !tile.Passable {
-		return false, nil
-	}
-
-	// Check directional blocks
-	for _, block := range tile.BlockedDirections {
-		if block.Direction == fromDirection && (block.BlockInbound || block.BlockOutbound) {
-			return false, nil
-		}
-	}
-
-	return true, nil
+	return tile.CanEnter(fromDirection), nil
 }
 
 // NewMap creates a new map with default values.
@@ -10,5 +10,7 @@
 // - types.go: Core data types and constants
 // - tile.go: Tile struct and methods
 // - map.go: Map struct and methods
+// - diff.go: Comparing two versions of a map
+// - validate.go: Checking a map before it is saved
 // - maps_test.go: Comprehensive test suite
 package maps
@@ -141,6 +141,29 @@ func (s *MapSuite) TestPassability() {
 	passable, err = s.m.IsPassable(1, 1, East)
 	s.Require().NoError(err)
 	s.True(passable)
+
+	// Outbound blocks stop leaving through a side, not entering it
+	exitBlockedTile := Tile{
+		Passable: true,
+		BlockedDirections: []DirectionalBlock{
+			{Direction: West, BlockInbound: false, BlockOutbound: true},
+		},
+	}
+	err = s.m.SetTile(2, 2, exitBlockedTile)
+	s.Require().NoError(err)
+
+	passable, err = s.m.IsPassable(2, 2, West)
+	s.Require().NoError(err)
+	s.True(passable)
+
+	passable, err = s.m.IsPassable(2, 2, North)
+	s.Require().NoError(err)
+	s.True(passable)
+
+	s.Require().NoError(s.m.SetTile(3, 3, Tile{Passable: false}))
+	passable, err = s.m.IsPassable(3, 3, North)
+	s.Require().NoError(err)
+	s.False(passable)
 }
 
 func (s *MapSuite) TestWarpFunctionality() {
@@ -270,6 +293,144 @@ func (s *MapSuite) TestDirectionDelta() {
 	s.Equal(0, dy)
 }
 
+func (s *MapSuite) TestDirectionOpposite() {
+	s.Equal(South, North.Opposite())
+	s.Equal(West, East.Opposite())
+	s.Equal(North, South.Opposite())
+	s.Equal(East, West.Opposite())
+	s.False(Direction(99).Valid())
+	s.True(West.Valid())
+}
+
+func (s *MapSuite) TestTileEntryAndExit() {
+	tile := Tile{
+		Passable: true,
+		BlockedDirections: []DirectionalBlock{
+			{Direction: North, BlockInbound: true},
+			{Direction: East, BlockOutbound: true},
+		},
+	}
+
+	s.False(tile.CanEnter(North))
+	s.True(tile.CanExit(North))
+	s.True(tile.CanEnter(East))
+	s.False(tile.CanExit(East))
+	s.True(tile.CanEnter(South))
+
+	tile.Passable = false
+	s.False(tile.CanEnter(South), "impassable tiles cannot be entered from any side")
+	s.True(tile.CanExit(South), "impassable tiles can still be left")
+}
+
+func (s *MapSuite) TestLinks() {
+	links := MapLinks{North: 2, East: 3, South: 4, West: 5}
+	s.Equal(2, links.Get(North))
+	s.Equal(3, links.Get(East))
+	s.Equal(4, links.Get(South))
+	s.Equal(5, links.Get(West))
+	s.Zero(links.Get(Direction(99)))
+}
+
+func (s *MapSuite) TestInBounds() {
+	s.True(InBounds(0, 0))
+	s.True(InBounds(Size-1, Size-1))
+	s.False(InBounds(-1, 0))
+	s.False(InBounds(0, Size))
+}
+
+func (s *MapSuite) TestUnmarshalWithoutTimestamp() {
+	var m Map
+	s.Require().NoError(json.Unmarshal([]byte(`{"name": "Fresh", "tags": ["new"]}`), &m))
+	s.Equal("Fresh", m.Name)
+	s.True(m.LastUpdated.IsZero())
+
+	s.Error(json.Unmarshal([]byte(`{"name": "Fresh", "last_updated": "yesterday"}`), &m))
+}
+
+func (s *MapSuite) TestCompare() {
+	before := *s.m
+	after := *s.m
+	after.Version = 2
+	after.Name = "Renamed"
+	after.Attributes = map[string]string{"difficulty": "hard", "music": "town"}
+	after.Links = MapLinks{North: 3, East: 4}
+	after.Tiles[1][2].Passable = true
+	after.Tiles[1][2].AddGraphic(0, Graphic{GraphicID: 7})
+	after.Tiles[3][4].Attributes = map[string]string{"shop": "yes"}
+	after.Tiles[5][6].Graphics = map[int]Graphic{}
+
+	d := Compare(&before, &after)
+	s.Equal(1, d.FromVersion)
+	s.Equal(2, d.ToVersion)
+	s.Equal([]string{"name"}, d.Fields)
+
+	s.Require().Len(d.Attributes, 2)
+	s.Equal("difficulty", d.Attributes[0].Key)
+	s.Equal("easy", *d.Attributes[0].From)
+	s.Equal("hard", *d.Attributes[0].To)
+	s.Equal("music", d.Attributes[1].Key)
+	s.Nil(d.Attributes[1].From)
+
+	s.Equal([]LinkChange{{Direction: North, From: 2, To: 3}, {Direction: East, From: 0, To: 4}}, d.Links)
+
+	s.Require().Len(d.Tiles, 2, "an empty graphics map is no change")
+	s.Equal(1, d.Tiles[0].X)
+	s.Equal(2, d.Tiles[0].Y)
+	s.Equal([]string{"passable"}, d.Tiles[0].Fields)
+	s.Require().Len(d.Tiles[0].Graphics, 1)
+	s.Nil(d.Tiles[0].Graphics[0].From)
+	s.Equal(7, d.Tiles[0].Graphics[0].To.GraphicID)
+	s.Equal(3, d.Tiles[1].X)
+	s.Empty(d.Tiles[1].Fields)
+	s.Equal("shop", d.Tiles[1].Attributes[0].Key)
+
+	s.Empty(Compare(&before, &before).Tiles)
+}
+
+func (s *MapSuite) TestValidate() {
+	s.NoError(s.m.Validate())
+
+	m := NewMap(1, " ")
+	m.Links.East = -2
+	m.Tiles[3][4].Warp = &WarpDestination{MapID: 0, X: 1, Y: 1}
+	m.Tiles[5][6].BlockedDirections = []DirectionalBlock{{Direction: East}, {Direction: -1}, {Direction: East}}
+	m.Tiles[0][0].Graphics = map[int]Graphic{2: {GraphicID: -5}}
+
+	err := m.Validate()
+	var verr ValidationError
+	s.Require().ErrorAs(err, &verr)
+	fields := make([]string, len(verr))
+	for i, e := range verr {
+		fields[i] = e.Field
+	}
+	s.Equal([]string{
+		"name",
+		"links.east",
+		"tiles[0][0].graphics[2].graphic_id",
+		"tiles[3][4].warp.map_id",
+		"tiles[5][6].blocked_directions[1].direction",
+		"tiles[5][6].blocked_directions[2].direction",
+	}, fields)
+	s.Contains(verr[5].Message, "blocked_directions[0]")
+	s.Contains(err.Error(), "tiles[3][4].warp.map_id")
+}
+
+func (s *MapSuite) TestCheckReferences() {
+	exists := func(id int) bool { return id == 2 }
+	s.NoError(s.m.CheckReferences(exists))
+
+	s.m.Links.South = 9
+	s.m.Tiles[3][4].Warp = &WarpDestination{MapID: 8}
+	s.m.Tiles[5][5].Warp = &WarpDestination{MapID: s.m.ID}
+	err := s.m.CheckReferences(exists)
+	var verr ValidationError
+	s.Require().ErrorAs(err, &verr)
+	s.Equal([]FieldError{
+		{Field: "links.south", Message: "map 9 does not exist"},
+		{Field: "tiles[3][4].warp.map_id", Message: "map 8 does not exist"},
+	}, []FieldError(verr))
+}
+
 func TestMapSuite(t *testing.T) {
 	suite.Run(t, new(MapSuite))
 }
@@ -43,3 +43,26 @@ func (t *Tile) HasGraphic(zIndex int) bool {
 	_, exists := t.GetGraphic(zIndex)
 	return exists
 }
+
+// CanExit reports whether movement may leave this tile through side d.
+func (t *Tile) CanExit(d Direction) bool {
+	for _, block := range t.BlockedDirections {
+		if block.Direction == d && block.BlockOutbound {
+			return false
+		}
+	}
+	return true
+}
+
+// CanEnter reports whether movement may enter this tile through side from.
+func (t *Tile) CanEnter(from Direction) bool {
+	if !t.Passable {
+		return false
+	}
+	for _, block := range t.BlockedDirections {
+		if block.Direction == from && block.BlockInbound {
+			return false
+		}
+	}
+	return true
+}
@@ -27,6 +27,27 @@ func (d Direction) Delta() (int, int) {
 	}
 }
 
+// Opposite returns the direction facing the other way.
+func (d Direction) Opposite() Direction {
+	switch d {
+	case North:
+		return South
+	case East:
+		return West
+	case South:
+		return North
+	case West:
+		return East
+	default:
+		return d // Invalid direction
+	}
+}
+
+// Valid reports whether d is one of the four cardinal directions.
+func (d Direction) Valid() bool {
+	return d >= North && d <= West
+}
+
 // DirectionalBlock represents blocking rules for a direction.
 type DirectionalBlock struct {
 	Direction     Direction `json:"direction"`
@@ -54,3 +75,19 @@ type MapLinks struct {
 	South int `json:"south,omitempty"`
 	West  int `json:"west,omitempty"`
 }
+
+// Get returns the ID of the map linked in direction d, or 0 if there is none.
+func (l MapLinks) Get(d Direction) int {
+	switch d {
+	case North:
+		return l.North
+	case East:
+		return l.East
+	case South:
+		return l.South
+	case West:
+		return l.West
+	default:
+		return 0
+	}
+}
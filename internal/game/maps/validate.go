@@ -0,0 +1,131 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a problem with one field of a map. Field is a path such as
+// "tiles[3][4].warp.map_id", using the JSON field names.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every problem found in a map.
+type ValidationError []FieldError
+
+func (v ValidationError) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return "invalid map: " + strings.Join(msgs, "; ")
+}
+
+// validation collects field errors.
+type validation []FieldError
+
+func (v *validation) add(field, format string, args ...any) {
+	*v = append(*v, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v validation) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return ValidationError(v)
+}
+
+// directionNames gives the JSON field of each direction in MapLinks.
+var directionNames = [...]string{North: "north", East: "east", South: "south", West: "west"}
+
+// Validate checks that m is well formed on its own, returning a
+// ValidationError listing every problem. References to other maps are
+// checked by CheckReferences.
+func (m *Map) Validate() error {
+	var v validation
+	if strings.TrimSpace(m.Name) == "" {
+		v.add("name", "is required")
+	}
+	for i, tag := range m.Tags {
+		if strings.TrimSpace(tag) == "" {
+			v.add(fmt.Sprintf("tags[%d]", i), "must not be empty")
+		}
+	}
+	validateAttributes(&v, "attributes", m.Attributes)
+	for d := North; d <= West; d++ {
+		if id := m.Links.Get(d); id < 0 {
+			v.add("links."+directionNames[d], "must be a map ID or 0 for none, not %d", id)
+		}
+	}
+	for x := range m.Tiles {
+		for y := range m.Tiles[x] {
+			validateTile(&v, fmt.Sprintf("tiles[%d][%d]", x, y), &m.Tiles[x][y])
+		}
+	}
+	return v.err()
+}
+
+func validateTile(v *validation, path string, t *Tile) {
+	seen := make(map[Direction]int)
+	for i, block := range t.BlockedDirections {
+		field := fmt.Sprintf("%s.blocked_directions[%d].direction", path, i)
+		if !block.Direction.Valid() {
+			v.add(field, "must be 0 to 3 (north, east, south, west), not %d", block.Direction)
+			continue
+		}
+		if first, ok := seen[block.Direction]; ok {
+			v.add(field, "repeats blocked_directions[%d]", first)
+			continue
+		}
+		seen[block.Direction] = i
+	}
+	if w := t.Warp; w != nil {
+		if w.MapID < 1 {
+			v.add(path+".warp.map_id", "must be a map ID, not %d", w.MapID)
+		}
+		if !InBounds(w.X, w.Y) {
+			v.add(path+".warp", "destination (%d, %d) is outside the map", w.X, w.Y)
+		}
+	}
+	for _, layer := range sortedKeys(t.Graphics, nil) {
+		if id := t.Graphics[layer].GraphicID; id < 0 {
+			v.add(fmt.Sprintf("%s.graphics[%d].graphic_id", path, layer), "must not be negative")
+		}
+	}
+	validateAttributes(v, path+".attributes", t.Attributes)
+}
+
+func validateAttributes(v *validation, path string, attrs map[string]string) {
+	if _, ok := attrs[""]; ok {
+		v.add(path, "keys must not be empty")
+	}
+}
+
+// CheckReferences reports, as a ValidationError, links and warps in m that
+// name maps for which exists returns false. A map may always refer to
+// itself.
+func (m *Map) CheckReferences(exists func(id int) bool) error {
+	var v validation
+	check := func(field string, id int) {
+		if id > 0 && id != m.ID && !exists(id) {
+			v.add(field, "map %d does not exist", id)
+		}
+	}
+	for d := North; d <= West; d++ {
+		check("links."+directionNames[d], m.Links.Get(d))
+	}
+	for x := range m.Tiles {
+		for y := range m.Tiles[x] {
+			if w := m.Tiles[x][y].Warp; w != nil {
+				check(fmt.Sprintf("tiles[%d][%d].warp.map_id", x, y), w.MapID)
+			}
+		}
+	}
+	return v.err()
+}
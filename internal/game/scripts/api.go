@@ -0,0 +1,86 @@
+package scripts
+
+import (
+	"errors"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// hostKey is the thread local holding the Host for the running invocation.
+const hostKey = "host"
+
+// predeclared are the names visible to every script.
+var predeclared = starlark.StringDict{
+	"game": &starlarkstruct.Module{
+		Name: "game",
+		Members: starlark.StringDict{
+			"message":            starlark.NewBuiltin("message", message),
+			"warp":               starlark.NewBuiltin("warp", warp),
+			"set_tile_attribute": starlark.NewBuiltin("set_tile_attribute", setTileAttribute),
+			"give_item":          starlark.NewBuiltin("give_item", giveItem),
+		},
+	},
+}
+
+// hostOf returns the Host the thread is running for.
+func hostOf(thread *starlark.Thread) (Host, error) {
+	host, ok := thread.Local(hostKey).(Host)
+	if !ok {
+		// A script's top level runs without a trigger to act on.
+		return nil, errors.New("game API is only available inside trigger handlers")
+	}
+	return host, nil
+}
+
+func message(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var text string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "text", &text); err != nil {
+		return nil, err
+	}
+	host, err := hostOf(thread)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.None, host.Message(text)
+}
+
+func warp(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var mapID, x, y int
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "map_id", &mapID, "x", &x, "y", &y); err != nil {
+		return nil, err
+	}
+	host, err := hostOf(thread)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.None, host.Warp(mapID, x, y)
+}
+
+func setTileAttribute(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key, value string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "value", &value); err != nil {
+		return nil, err
+	}
+	host, err := hostOf(thread)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.None, host.SetTileAttribute(key, value)
+}
+
+func giveItem(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var itemID string
+	count := 1
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "item_id", &itemID, "count?", &count); err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, errors.New("give_item: count must be positive")
+	}
+	host, err := hostOf(thread)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.None, host.GiveItem(itemID, count)
+}
@@ -0,0 +1,142 @@
+package scripts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// Extension is the file extension of script files.
+const Extension = ".star"
+
+// ErrInvalidName is returned for script names that are not safe file names.
+var ErrInvalidName = errors.New("invalid script name")
+
+// validName matches names that map to a file directly inside the scripts
+// directory.
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Engine loads and runs trigger scripts. It is safe for concurrent use.
+type Engine struct {
+	dir    string
+	limits Limits
+
+	mu    sync.Mutex
+	cache map[string]*module
+}
+
+// module is a loaded script.
+type module struct {
+	modTime time.Time
+	globals starlark.StringDict
+}
+
+// New returns an Engine that loads scripts from dir, creating it if needed.
+func New(dir string, limits Limits) (*Engine, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Engine{
+		dir:    dir,
+		limits: limits,
+		cache:  make(map[string]*module),
+	}, nil
+}
+
+// Run invokes the handler for t.Event in the named script. A script without a
+// handler for the event is not an error.
+func (e *Engine) Run(name string, t Trigger, host Host) error {
+	mod, err := e.load(name)
+	if err != nil {
+		return err
+	}
+	fn, ok := mod.globals[t.Event.handler()]
+	if !ok {
+		return nil
+	}
+
+	thread := e.thread(name, host)
+	defer e.watch(thread)()
+
+	arg := starlarkstruct.FromStringDict(starlark.String("trigger"), starlark.StringDict{
+		"event":  starlark.String(t.Event),
+		"player": starlark.String(t.Player),
+		"map_id": starlark.MakeInt(t.MapID),
+		"x":      starlark.MakeInt(t.X),
+		"y":      starlark.MakeInt(t.Y),
+	})
+	if _, err := starlark.Call(thread, fn, starlark.Tuple{arg}, nil); err != nil {
+		return fmt.Errorf("script %s: %w", name, err)
+	}
+	return nil
+}
+
+// thread returns a new interpreter thread bound to host and the engine's step
+// limit. A nil host leaves the game API unavailable.
+func (e *Engine) thread(name string, host Host) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name:  name,
+		Print: func(*starlark.Thread, string) {},
+	}
+	thread.SetMaxExecutionSteps(e.limits.MaxSteps)
+	if host != nil {
+		thread.SetLocal(hostKey, host)
+	}
+	return thread
+}
+
+// watch cancels thread once the engine's timeout passes. Call the returned
+// function when the thread is done.
+func (e *Engine) watch(thread *starlark.Thread) func() {
+	if e.limits.Timeout <= 0 {
+		return func() {}
+	}
+	timer := time.AfterFunc(e.limits.Timeout, func() {
+		thread.Cancel("timeout")
+	})
+	return func() { timer.Stop() }
+}
+
+// load returns the named script, executing its top level on first use or
+// whenever the file has changed since it was last loaded.
+func (e *Engine) load(name string) (*module, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	path := filepath.Join(e.dir, name+Extension)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	mod, ok := e.cache[name]
+	e.mu.Unlock()
+	if ok && mod.modTime.Equal(info.ModTime()) {
+		return mod, nil
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	thread := e.thread(name, nil)
+	defer e.watch(thread)()
+	globals, err := starlark.ExecFile(thread, path, src, predeclared)
+	if err != nil {
+		return nil, fmt.Errorf("script %s: %w", name, err)
+	}
+
+	mod = &module{modTime: info.ModTime(), globals: globals}
+	e.mu.Lock()
+	e.cache[name] = mod
+	e.mu.Unlock()
+	return mod, nil
+}
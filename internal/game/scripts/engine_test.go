@@ -0,0 +1,154 @@
+package scripts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingHost is a Host that records what scripts ask of it.
+type recordingHost struct {
+	messages   []string
+	warps      [][3]int
+	attributes map[string]string
+	items      map[string]int
+}
+
+func newRecordingHost() *recordingHost {
+	return &recordingHost{attributes: map[string]string{}, items: map[string]int{}}
+}
+
+func (h *recordingHost) Message(text string) error {
+	h.messages = append(h.messages, text)
+	return nil
+}
+
+func (h *recordingHost) Warp(mapID, x, y int) error {
+	h.warps = append(h.warps, [3]int{mapID, x, y})
+	return nil
+}
+
+func (h *recordingHost) SetTileAttribute(key, value string) error {
+	h.attributes[key] = value
+	return nil
+}
+
+func (h *recordingHost) GiveItem(itemID string, count int) error {
+	h.items[itemID] += count
+	return nil
+}
+
+type EngineSuite struct {
+	suite.Suite
+	dir    string
+	engine *Engine
+	host   *recordingHost
+}
+
+func (s *EngineSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	engine, err := New(s.dir, DefaultLimits)
+	s.Require().NoError(err)
+	s.engine = engine
+	s.host = newRecordingHost()
+}
+
+func (s *EngineSuite) write(name, src string) {
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, name+Extension), []byte(src), 0o644))
+}
+
+func (s *EngineSuite) trigger(e Event) Trigger {
+	return Trigger{Event: e, Player: "hero", MapID: 1, X: 2, Y: 3}
+}
+
+func (s *EngineSuite) TestHandlersUseAPI() {
+	s.write("chest", `
+def on_enter(t):
+    game.message("welcome %s to %d,%d on map %d" % (t.player, t.x, t.y, t.map_id))
+    game.set_tile_attribute("visited", "yes")
+
+def on_interact(t):
+    game.give_item("gold", count=5)
+    game.give_item("key")
+    game.warp(2, 8, 9)
+`)
+
+	s.Require().NoError(s.engine.Run("chest", s.trigger(Enter), s.host))
+	s.Equal([]string{"welcome hero to 2,3 on map 1"}, s.host.messages)
+	s.Equal("yes", s.host.attributes["visited"])
+
+	s.Require().NoError(s.engine.Run("chest", s.trigger(Interact), s.host))
+	s.Equal(map[string]int{"gold": 5, "key": 1}, s.host.items)
+	s.Equal([][3]int{{2, 8, 9}}, s.host.warps)
+}
+
+func (s *EngineSuite) TestMissingHandlerIsNotAnError() {
+	s.write("quiet", "def on_enter(t):\n    pass\n")
+	s.NoError(s.engine.Run("quiet", s.trigger(Leave), s.host))
+}
+
+func (s *EngineSuite) TestMissingScript() {
+	s.Error(s.engine.Run("nope", s.trigger(Enter), s.host))
+}
+
+func (s *EngineSuite) TestInvalidName() {
+	s.ErrorIs(s.engine.Run("../secrets", s.trigger(Enter), s.host), ErrInvalidName)
+}
+
+func (s *EngineSuite) TestStepLimit() {
+	engine, err := New(s.dir, Limits{MaxSteps: DefaultLimits.MaxSteps})
+	s.Require().NoError(err)
+	s.write("spin", `
+def on_enter(t):
+    for i in range(1000000000):
+        pass
+`)
+	start := time.Now()
+	err = engine.Run("spin", s.trigger(Enter), s.host)
+	s.ErrorContains(err, "too many steps")
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *EngineSuite) TestTimeout() {
+	engine, err := New(s.dir, Limits{Timeout: 20 * time.Millisecond})
+	s.Require().NoError(err)
+	s.write("spin", `
+def on_enter(t):
+    for i in range(1000000000):
+        pass
+`)
+	start := time.Now()
+	err = engine.Run("spin", s.trigger(Enter), s.host)
+	s.ErrorContains(err, "timeout")
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *EngineSuite) TestTopLevelCannotUseAPI() {
+	s.write("eager", "game.message(\"hi\")\n")
+	s.Error(s.engine.Run("eager", s.trigger(Enter), s.host))
+	s.Empty(s.host.messages)
+}
+
+func (s *EngineSuite) TestNoLoad() {
+	s.write("loader", "load(\"other.star\", \"x\")\n")
+	s.Error(s.engine.Run("loader", s.trigger(Enter), s.host))
+}
+
+func (s *EngineSuite) TestReloadsChangedScript() {
+	s.write("sign", "def on_interact(t):\n    game.message(\"old\")\n")
+	s.Require().NoError(s.engine.Run("sign", s.trigger(Interact), s.host))
+
+	s.write("sign", "def on_interact(t):\n    game.message(\"new\")\n")
+	later := time.Now().Add(time.Second)
+	s.Require().NoError(os.Chtimes(filepath.Join(s.dir, "sign"+Extension), later, later))
+	s.Require().NoError(s.engine.Run("sign", s.trigger(Interact), s.host))
+
+	s.Equal([]string{"old", "new"}, s.host.messages)
+}
+
+func TestEngine(t *testing.T) {
+	suite.Run(t, new(EngineSuite))
+}
@@ -0,0 +1,71 @@
+// Package scripts runs the tile trigger scripts referenced by maps.Tile.Trigger.
+//
+// Scripts are written in Starlark (a small, deterministic dialect of Python)
+// and stored as <name>.star files in the data root's scripts directory. A
+// script reacts to a trigger by defining any of the functions on_enter,
+// on_leave and on_interact, each taking a single trigger argument with the
+// fields event, player, map_id, x and y.
+//
+// Scripts have no access to the filesystem, network or clock. The only way
+// they can affect the game is through the predeclared game module:
+//
+//	game.message(text)                  send text to the triggering player
+//	game.warp(map_id, x, y)             move the triggering player
+//	game.set_tile_attribute(key, value) set an attribute on the trigger tile
+//	game.give_item(item_id, count=1)    add items to the player's saved inventory
+//
+// Every invocation runs under Limits so a misbehaving script cannot stall the
+// game loop.
+package scripts
+
+import "time"
+
+// Event is the kind of interaction that fired a trigger.
+type Event string
+
+const (
+	// Enter fires when a player finishes moving onto the tile.
+	Enter Event = "enter"
+	// Leave fires when a player moves off the tile.
+	Leave Event = "leave"
+	// Interact fires when a player interacts with the tile it is facing.
+	Interact Event = "interact"
+)
+
+// handler returns the name of the script function that handles e.
+func (e Event) handler() string {
+	return "on_" + string(e)
+}
+
+// Trigger describes the tile and player a script is running for.
+type Trigger struct {
+	Event  Event
+	Player string
+	MapID  int
+	X      int
+	Y      int
+}
+
+// Host carries out the actions a script requests. It is implemented by the
+// game and always acts on the Trigger the script was invoked for.
+type Host interface {
+	Message(text string) error
+	Warp(mapID, x, y int) error
+	SetTileAttribute(key, value string) error
+	GiveItem(itemID string, count int) error
+}
+
+// Limits bound the work a single invocation may do. A zero field means no
+// limit.
+type Limits struct {
+	// MaxSteps is the maximum number of interpreter steps.
+	MaxSteps uint64
+	// Timeout is the maximum wall clock time.
+	Timeout time.Duration
+}
+
+// DefaultLimits keep a trigger well inside a single 20Hz tick.
+var DefaultLimits = Limits{
+	MaxSteps: 100_000,
+	Timeout:  10 * time.Millisecond,
+}
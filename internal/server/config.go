@@ -1,12 +1,225 @@
 package server
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/game"
+	"github.com/Odyssey-Classic/server/internal/services/registry"
+)
+
 type Config struct {
-	Ports   Ports
-	DataDir string
+	// Name is the server's name as listed by the registry.
+	Name string `yaml:"name"`
+	// Version is the server build version reported to the registry. It comes
+	// from the build, not the config file.
+	Version string `yaml:"-"`
+	// Capacity is the most players the server advertises room for.
+	Capacity int `yaml:"capacity"`
+	// Registry is the URL of the Odyssey Registry the server is listed with.
+	// When empty the server runs unlisted.
+	Registry string `yaml:"registry"`
+
+	Listen   Listen      `yaml:"listen"`
+	DataDir  string      `yaml:"data_dir"`
+	LogLevel slog.Level  `yaml:"log_level"`
+	Auth     Auth        `yaml:"auth"`
+	Game     game.Config `yaml:"game"`
+
+	// ShutdownTimeout bounds how long a graceful shutdown may take. Zero uses
+	// DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// DefaultShutdownTimeout is used when Config.ShutdownTimeout is zero.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// MaxTickRate is the fastest simulation rate Validate accepts.
+const MaxTickRate = 1000
+
+// Listen holds the address each service binds, such as ":8080" or
+// "127.0.0.1:8081".
+type Listen struct {
+	Admin   string `yaml:"admin"`
+	Meta    string `yaml:"meta"`
+	Network string `yaml:"network"`
+}
+
+// Auth configures how player bearer tokens are issued and verified.
+// Exactly one of Secret or PublicKeyFile must be set.
+type Auth struct {
+	// Secret is the shared HMAC secret tokens are signed with. The Meta
+	// service only issues tokens when it is set.
+	Secret string `yaml:"secret"`
+	// PublicKeyFile is the path to a PEM encoded public key tokens are
+	// verified against.
+	PublicKeyFile string `yaml:"public_key_file"`
+	// Audience, when set, must appear in each token's "aud" claim.
+	Audience string `yaml:"audience"`
+	// TokenTTL is how long tokens issued by Meta stay valid. Zero uses
+	// auth.DefaultTokenTTL.
+	TokenTTL time.Duration `yaml:"token_ttl"`
+}
+
+// DefaultConfig returns the settings used for anything a config file,
+// environment variable or flag does not set.
+func DefaultConfig() Config {
+	return Config{
+		Name:     "Odyssey",
+		Capacity: 100,
+		Listen: Listen{
+			Admin:   ":8081",
+			Meta:    ":8082",
+			Network: ":8080",
+		},
+		DataDir:         "data",
+		LogLevel:        slog.LevelInfo,
+		ShutdownTimeout: DefaultShutdownTimeout,
+		Game: game.Config{
+			TickRate: game.DefaultTickRate,
+			Spawn:    game.Location{MapID: 1, X: 8, Y: 8},
+		},
+	}
+}
+
+// LoadConfigFile reads the YAML file at path over cfg. Settings the file
+// leaves out keep their current values; unknown settings are an error.
+func LoadConfigFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every setting that is out of range, naming each by its
+// config file key.
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	addrs := []struct{ key, addr string }{
+		{"listen.admin", c.Listen.Admin},
+		{"listen.meta", c.Listen.Meta},
+		{"listen.network", c.Listen.Network},
+	}
+	seen := map[string]string{}
+	for _, a := range addrs {
+		port, err := portOf(a.addr)
+		if err != nil {
+			invalid(a.key, "%q is not a host:port address", a.addr)
+			continue
+		}
+		if other, ok := seen[a.addr]; ok && port != 0 {
+			invalid(a.key, "%q is already used by %s", a.addr, other)
+		}
+		seen[a.addr] = a.key
+	}
+
+	if c.DataDir == "" {
+		invalid("data_dir", "is required")
+	}
+	if c.Capacity < 0 {
+		invalid("capacity", "must not be negative, got %d", c.Capacity)
+	}
+	if c.Registry != "" {
+		if _, err := registry.ParseAndValidateURL(c.Registry); err != nil {
+			invalid("registry", "%q is not an absolute URL", c.Registry)
+		}
+	}
+	if c.ShutdownTimeout < 0 {
+		invalid("shutdown_timeout", "must not be negative, got %s", c.ShutdownTimeout)
+	}
+
+	switch {
+	case c.Auth.Secret != "" && c.Auth.PublicKeyFile != "":
+		invalid("auth", "only one of secret or public_key_file may be set")
+	case c.Auth.Secret == "" && c.Auth.PublicKeyFile == "":
+		invalid("auth", "a secret or public_key_file is required")
+	}
+	if c.Auth.TokenTTL < 0 {
+		invalid("auth.token_ttl", "must not be negative, got %s", c.Auth.TokenTTL)
+	}
+
+	if c.Game.TickRate > MaxTickRate {
+		invalid("game.tick_rate", "must be at most %d, got %d", MaxTickRate, c.Game.TickRate)
+	}
+	if c.Game.Spawn.MapID < 1 {
+		invalid("game.spawn.map", "must be a map ID, got %d", c.Game.Spawn.MapID)
+	}
+	if c.Game.Spawn.X < 0 || c.Game.Spawn.X >= maps.Size {
+		invalid("game.spawn.x", "must be from 0 to %d, got %d", maps.Size-1, c.Game.Spawn.X)
+	}
+	if c.Game.Spawn.Y < 0 || c.Game.Spawn.Y >= maps.Size {
+		invalid("game.spawn.y", "must be from 0 to %d, got %d", maps.Size-1, c.Game.Spawn.Y)
+	}
+	if c.Game.Chat.MaxLength < 0 {
+		invalid("game.chat.max_length", "must not be negative, got %d", c.Game.Chat.MaxLength)
+	}
+	if c.Game.Chat.Rate < 0 {
+		invalid("game.chat.rate", "must not be negative, got %g", c.Game.Chat.Rate)
+	}
+	if c.Game.Chat.Burst < 0 {
+		invalid("game.chat.burst", "must not be negative, got %d", c.Game.Chat.Burst)
+	}
+
+	return errors.Join(errs...)
+}
+
+// portOf returns the port of a host:port address.
+func portOf(addr string) (uint16, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return 0, err
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(p), nil
+}
+
+// issuer returns the token issuer for Meta, or nil when tokens are signed
+// elsewhere.
+func (a Auth) issuer() *auth.Issuer {
+	if a.Secret == "" {
+		return nil
+	}
+	ttl := a.TokenTTL
+	if ttl == 0 {
+		ttl = auth.DefaultTokenTTL
+	}
+	return auth.NewHMACIssuer([]byte(a.Secret), a.Audience, ttl)
 }
 
-type Ports struct {
-	Admin   uint16
-	Meta    uint16
-	Network uint16
+func (a Auth) verifier() (auth.Verifier, error) {
+	switch {
+	case a.Secret != "" && a.PublicKeyFile != "":
+		return nil, errors.New("auth: only one of secret or public key file may be set")
+	case a.Secret != "":
+		return auth.NewHMACVerifier([]byte(a.Secret), a.Audience), nil
+	case a.PublicKeyFile != "":
+		return auth.NewPublicKeyVerifier(a.PublicKeyFile, a.Audience)
+	default:
+		return nil, errors.New("auth: a secret or public key file is required")
+	}
 }
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/services/game"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+// write saves contents as a config file and returns its path.
+func (s *ConfigSuite) write(contents string) string {
+	path := filepath.Join(s.T().TempDir(), "config.yaml")
+	s.Require().NoError(os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func (s *ConfigSuite) TestDefaultsNeedOnlyAuth() {
+	cfg := DefaultConfig()
+	s.ErrorContains(cfg.Validate(), "auth: a secret or public_key_file is required")
+
+	cfg.Auth.Secret = "secret"
+	s.NoError(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestLoadConfigFile() {
+	path := s.write(`
+name: Test Realm
+registry: https://registry.example.com
+listen:
+  admin: 127.0.0.1:9081
+log_level: debug
+shutdown_timeout: 3s
+auth:
+  secret: hunter2
+  token_ttl: 1h
+game:
+  tick_rate: 30
+  duplicate_login: reject
+  spawn:
+    map: 2
+`)
+	cfg := DefaultConfig()
+	s.Require().NoError(LoadConfigFile(path, &cfg))
+
+	s.Equal("Test Realm", cfg.Name)
+	s.Equal("https://registry.example.com", cfg.Registry)
+	s.Equal("127.0.0.1:9081", cfg.Listen.Admin)
+	s.Equal(slog.LevelDebug, cfg.LogLevel)
+	s.Equal(3*time.Second, cfg.ShutdownTimeout)
+	s.Equal("hunter2", cfg.Auth.Secret)
+	s.Equal(time.Hour, cfg.Auth.TokenTTL)
+	s.Equal(uint16(30), cfg.Game.TickRate)
+	s.Equal(game.DuplicateLoginReject, cfg.Game.DuplicateLogin)
+	s.Equal(game.Location{MapID: 2, X: 8, Y: 8}, cfg.Game.Spawn)
+
+	// Anything the file leaves out keeps its default.
+	s.Equal(":8080", cfg.Listen.Network)
+	s.Equal("data", cfg.DataDir)
+	s.NoError(cfg.Validate())
+}
+
+func (s *ConfigSuite) TestLoadEmptyFile() {
+	cfg := DefaultConfig()
+	s.Require().NoError(LoadConfigFile(s.write(""), &cfg))
+	s.Equal(DefaultConfig(), cfg)
+}
+
+func (s *ConfigSuite) TestLoadRejectsUnknownSettings() {
+	cfg := DefaultConfig()
+	err := LoadConfigFile(s.write("game:\n  tickrate: 30\n"), &cfg)
+	s.ErrorContains(err, "field tickrate not found")
+}
+
+func (s *ConfigSuite) TestLoadRejectsBadValues() {
+	for _, contents := range []string{
+		"log_level: loud\n",
+		"shutdown_timeout: soon\n",
+		"game:\n  tick_rate: -1\n",
+		"game:\n  duplicate_login: maybe\n",
+	} {
+		cfg := DefaultConfig()
+		s.Error(LoadConfigFile(s.write(contents), &cfg), contents)
+	}
+}
+
+func (s *ConfigSuite) TestLoadMissingFile() {
+	cfg := DefaultConfig()
+	s.Error(LoadConfigFile(filepath.Join(s.T().TempDir(), "missing.yaml"), &cfg))
+}
+
+func (s *ConfigSuite) TestValidateReportsEveryProblem() {
+	cfg := DefaultConfig()
+	cfg.Listen.Admin = "8081"
+	cfg.Listen.Meta = ":8080"
+	cfg.DataDir = ""
+	cfg.Registry = "registry.example.com"
+	cfg.Auth = Auth{Secret: "secret", PublicKeyFile: "key.pem"}
+	cfg.Game.TickRate = MaxTickRate + 1
+	cfg.Game.Spawn = game.Location{MapID: 0, X: -1, Y: 17}
+	cfg.Game.Chat.Burst = -1
+
+	err := cfg.Validate()
+	s.Require().Error(err)
+	for _, want := range []string{
+		`listen.admin: "8081" is not a host:port address`,
+		`listen.network: ":8080" is already used by listen.meta`,
+		"data_dir: is required",
+		`registry: "registry.example.com" is not an absolute URL`,
+		"auth: only one of secret or public_key_file may be set",
+		"game.tick_rate: must be at most 1000, got 1001",
+		"game.spawn.map: must be a map ID, got 0",
+		"game.spawn.x: must be from 0 to 16, got -1",
+		"game.spawn.y: must be from 0 to 16, got 17",
+		"game.chat.burst: must not be negative, got -1",
+	} {
+		s.Contains(err.Error(), want)
+	}
+}
+
+func (s *ConfigSuite) TestEphemeralPortsMayRepeat() {
+	cfg := DefaultConfig()
+	cfg.Auth.Secret = "secret"
+	cfg.Listen = Listen{Admin: ":0", Meta: ":0", Network: ":0"}
+	s.NoError(cfg.Validate())
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
@@ -3,14 +3,20 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/Odyssey-Classic/server/internal/data"
+	"github.com/Odyssey-Classic/server/internal/game/scripts"
 	"github.com/Odyssey-Classic/server/internal/services/admin"
+	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
 	"github.com/Odyssey-Classic/server/internal/services/game"
 	"github.com/Odyssey-Classic/server/internal/services/meta"
 	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/internal/services/registry"
 )
 
 type Server struct {
@@ -20,22 +26,65 @@ type Server struct {
 	meta    *meta.Meta
 	network *network.Network
 	game    *game.Game
+	// registry is nil unless WithRegistry was given.
+	registry *registry.Client
 
 	// Applied via Option
 	registryURL *url.URL
 
+	shutdownTimeout time.Duration
+
 	wg *sync.WaitGroup
 }
 
+// NewServer validates cfg and builds the services it describes.
 func NewServer(cfg Config, options ...Option) (*Server, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	server := &Server{
-		wg: &sync.WaitGroup{},
+		wg:              &sync.WaitGroup{},
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+	if server.shutdownTimeout == 0 {
+		server.shutdownTimeout = DefaultShutdownTimeout
+	}
+
+	verifier, err := cfg.Auth.verifier()
+	if err != nil {
+		return nil, err
+	}
+
+	root := data.NewOSRoot(cfg.DataDir)
+	// The game and admin share the store; admin tells the game what changed.
+	maps, err := filestore.New(root.MapsDir())
+	if err != nil {
+		return nil, err
+	}
+	triggers, err := scripts.New(root.ScriptsDir(), scripts.DefaultLimits)
+	if err != nil {
+		return nil, err
 	}
 
-	server.admin = admin.New(cfg.Ports.Admin, data.NewOSRoot(cfg.DataDir))
-	server.meta = meta.New(cfg.Ports.Meta)
-	server.network = network.New(cfg.Ports.Network)
-	server.game = game.New(server.network.Out)
+	server.meta, err = meta.New(cfg.Listen.Meta, root, cfg.Auth.issuer(), verifier)
+	if err != nil {
+		return nil, err
+	}
+	// A character deleted through Meta must not join on a token issued
+	// before, and items given in game are saved with the character. Without a
+	// local Meta the characters live elsewhere.
+	var characters game.Characters
+	if store := server.meta.Characters(); store != nil {
+		characters = store
+	}
+
+	server.network = network.New(cfg.Listen.Network, verifier)
+	server.game = game.New(server.network.Out, server.network.In, maps, triggers, characters, cfg.Game)
+	server.admin, err = admin.New(cfg.Listen.Admin, root, maps, server.game)
+	if err != nil {
+		return nil, err
+	}
 
 	// errors.Join will keep this value `nil` if no new errors are added.
 	var optErrs error
@@ -43,45 +92,116 @@ func NewServer(cfg Config, options ...Option) (*Server, error) {
 		optErrs = errors.Join(optErrs, opt(server))
 	}
 
-	return server, optErrs
+	if optErrs != nil {
+		return nil, optErrs
+	}
+
+	if server.registryURL != nil {
+		// Validate has already checked the addresses.
+		metaPort, _ := portOf(cfg.Listen.Meta)
+		networkPort, _ := portOf(cfg.Listen.Network)
+		server.registry = registry.New(server.registryURL, registry.Info{
+			Name:     cfg.Name,
+			Version:  cfg.Version,
+			Ports:    registry.Ports{Meta: metaPort, Network: networkPort},
+			Capacity: cfg.Capacity,
+		}, server.network.ClientCount)
+	}
+
+	return server, nil
 }
 
+// Start starts every service and returns once they are running. If a service
+// fails to start, for example because its port is taken, the ones already
+// running are stopped and its error is returned. When ctx ends the services are shut down
+// in order, within the configured shutdown timeout; wg is released once that
+// is done.
 func (s *Server) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	var err error
 	s.once.Do(func() {
-		err = s.start(ctx)
+		err = s.start(ctx, wg)
 	})
 	return err
 }
 
-func (s *Server) start(ctx context.Context) error {
-	var startErr error
-	startErr = errors.Join(startErr, s.admin.Start(ctx, s.wg))
-	startErr = errors.Join(startErr, s.meta.Start(ctx, s.wg))
-	startErr = errors.Join(startErr, s.network.Start(ctx, s.wg))
-	startErr = errors.Join(startErr, s.game.Start(ctx, s.wg))
+func (s *Server) start(ctx context.Context, wg *sync.WaitGroup) error {
+	// Services must outlive ctx so that shutdown can stop them one at a time.
+	svcCtx := context.WithoutCancel(ctx)
 
-	if startErr != nil {
-		s.stop()
-		return startErr
+	steps := []startStep{s.admin.Start, s.meta.Start, s.network.Start, s.game.Start}
+	if s.registry != nil {
+		// Only advertise the server once everything else is running.
+		steps = append(steps, s.registry.Start)
+	}
+	for _, start := range steps {
+		if err := start(svcCtx, s.wg); err != nil {
+			// Roll back whatever already started. Services that never
+			// started have nothing to stop.
+			if stopErr := s.stop(); stopErr != nil {
+				slog.Error("rollback after failed start incomplete", "error", stopErr)
+			}
+			return err
+		}
 	}
 
-	s.wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := s.stop(); err != nil {
+			slog.Error("server shutdown incomplete", "error", err)
+		}
+	}()
 	return nil
 }
 
-// stop shuts down all sub-services
-func (s *Server) stop() {
-	if s.admin != nil {
-		s.admin.Stop()
-	}
-	if s.meta != nil {
-		s.meta.Stop()
+// startStep starts one service, returning once it is running.
+type startStep func(context.Context, *sync.WaitGroup) error
+
+// shutdownStep is one stage of an ordered shutdown.
+type shutdownStep struct {
+	name string
+	stop func(context.Context) error
+}
+
+// stop shuts the services down in order: leave the registry, stop accepting
+// players, let the game say goodbye and flush, drain the network, then close
+// the HTTP services. Each step shares the shutdown deadline.
+func (s *Server) stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	slog.Info("server shutting down", "timeout", s.shutdownTimeout)
+
+	var steps []shutdownStep
+	if s.registry != nil {
+		steps = append(steps, shutdownStep{"registry", s.registry.Stop})
 	}
-	if s.network != nil {
-		s.network.Stop()
+	steps = append(steps,
+		shutdownStep{"network listener", s.network.StopAccepting},
+		shutdownStep{"game", s.game.Stop},
+		shutdownStep{"network", s.network.Stop},
+		shutdownStep{"meta", s.meta.Stop},
+		shutdownStep{"admin", s.admin.Stop},
+	)
+
+	var err error
+	for _, step := range steps {
+		if stepErr := step.stop(ctx); stepErr != nil {
+			slog.Error("shutdown step failed", "step", step.name, "error", stepErr)
+			err = errors.Join(err, fmt.Errorf("%s: %w", step.name, stepErr))
+		}
 	}
-	if s.game != nil {
-		s.game.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		slog.Info("server shutdown complete")
+	case <-ctx.Done():
+		err = errors.Join(err, fmt.Errorf("services still running: %w", ctx.Err()))
 	}
+	return err
 }
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
+	"github.com/Odyssey-Classic/server/internal/services/game"
+	"github.com/Odyssey-Classic/server/internal/services/meta/characters"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+const testSecret = "test-secret"
+
+// freeAddr returns a loopback address that was free a moment ago.
+func freeAddr(s *suite.Suite) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+type ServerSuite struct {
+	suite.Suite
+	cfg       Config
+	character *characters.Character
+}
+
+func (s *ServerSuite) SetupTest() {
+	dir := s.T().TempDir()
+	maps, err := filestore.New(filepath.Join(dir, "maps"))
+	s.Require().NoError(err)
+	spawn, err := maps.Create("Spawn")
+	s.Require().NoError(err)
+	// The game only lets in characters that exist.
+	chars, err := characters.NewFileStore(data.NewOSRoot(dir).CharactersDir())
+	s.Require().NoError(err)
+	s.character, err = chars.Create("account-1", "Hero")
+	s.Require().NoError(err)
+
+	s.cfg = Config{
+		Listen: Listen{
+			Admin:   freeAddr(&s.Suite),
+			Meta:    freeAddr(&s.Suite),
+			Network: freeAddr(&s.Suite),
+		},
+		DataDir:         dir,
+		Auth:            Auth{Secret: testSecret},
+		Game:            game.Config{Spawn: game.Location{MapID: spawn.ID, X: 8, Y: 8}},
+		ShutdownTimeout: 5 * time.Second,
+	}
+}
+
+// connect joins the game as a new character and waits for the spawn map.
+func (s *ServerSuite) connect() *websocket.Conn {
+	token, _, err := auth.NewHMACIssuer([]byte(testSecret), "", time.Minute).Issue(s.character.AccountID, s.character.ID, s.character.Name)
+	s.Require().NoError(err)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+s.cfg.Listen.Network+"/", header)
+	s.Require().NoError(err)
+
+	s.NotNil(s.read(conn).GetMapChange())
+	return conn
+}
+
+func (s *ServerSuite) read(conn *websocket.Conn) *pb.GameMessage {
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	s.Require().NoError(err)
+	var msg pb.GameMessage
+	s.Require().NoError(proto.Unmarshal(data, &msg))
+	return &msg
+}
+
+func (s *ServerSuite) TestShutdownDisconnectsPlayers() {
+	srv, err := NewServer(s.cfg)
+	s.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	s.Require().NoError(srv.Start(ctx, wg))
+
+	conn := s.connect()
+	defer conn.Close()
+
+	cancel()
+
+	bye := s.read(conn).GetDisconnect()
+	s.Require().NotNil(bye)
+	s.Equal(pb.DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN, bye.Reason)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(s.cfg.ShutdownTimeout):
+		s.Fail("server did not shut down")
+	}
+
+	// Every listener is closed.
+	for _, addr := range []string{s.cfg.Listen.Admin, s.cfg.Listen.Meta, s.cfg.Listen.Network} {
+		_, err := net.Dial("tcp", addr)
+		s.Error(err, "%s still open", addr)
+	}
+}
+
+func (s *ServerSuite) TestStartRollsBackOnBindFailure() {
+	taken, err := net.Listen("tcp", s.cfg.Listen.Network)
+	s.Require().NoError(err)
+	defer taken.Close()
+
+	srv, err := NewServer(s.cfg)
+	s.Require().NoError(err)
+	wg := &sync.WaitGroup{}
+	err = srv.Start(context.Background(), wg)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "network:")
+	wg.Wait()
+
+	// Admin and meta started first and have let go of their ports.
+	for _, addr := range []string{s.cfg.Listen.Admin, s.cfg.Listen.Meta} {
+		ln, err := net.Listen("tcp", addr)
+		s.Require().NoError(err, "%s still bound", addr)
+		ln.Close()
+	}
+}
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
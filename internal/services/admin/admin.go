@@ -4,46 +4,67 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"sync"
-	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/Odyssey-Classic/server/internal/data"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
 	"github.com/Odyssey-Classic/server/internal/web"
 )
 
 type Admin struct {
 	wg       *sync.WaitGroup
-	port     uint16
+	addr     string
 	once     sync.Once
 	adminAPI *API
 	dataRoot data.Root
+	srv      *http.Server
 }
 
-func New(port uint16, root data.Root) *Admin {
+// New creates the Admin service, editing the maps in mapStore and telling game
+// about the changes. It fails if the administrator accounts under root cannot
+// be loaded.
+func New(addr string, root data.Root, mapStore store.MapStore, game Game) (*Admin, error) {
+	adminAPI, err := api(root, mapStore, game)
+	if err != nil {
+		return nil, err
+	}
 	return &Admin{
-		port:     port,
+		addr:     addr,
 		dataRoot: root,
-		adminAPI: api(root),
-	}
+		adminAPI: adminAPI,
+	}, nil
 }
 
+// Start binds the admin address and begins serving. It returns an error if the
+// address cannot be bound.
 func (a *Admin) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	var startErr error
 	a.once.Do(func() {
+		ln, err := net.Listen("tcp", a.addr)
+		if err != nil {
+			startErr = fmt.Errorf("admin: %w", err)
+			return
+		}
+		a.srv = &http.Server{
+			Handler:     a.routes(),
+			BaseContext: func(net.Listener) context.Context { return ctx },
+		}
+
 		a.wg = wg
 		a.wg.Add(1)
 		go func() {
-			startErr = a.start(ctx)
+			a.serve(ln)
 			a.wg.Done()
 		}()
 	})
 	return startErr
 }
 
-func (a *Admin) start(ctx context.Context) error {
+func (a *Admin) routes() http.Handler {
 	r := chi.NewRouter()
 
 	// Mount the admin API routes (they are already scoped under /admin inside the API router)
@@ -62,32 +83,26 @@ func (a *Admin) start(ctx context.Context) error {
 	})
 	r.Handle("/*", web.SPAHandler())
 
-	srv := &http.Server{
-		Addr:    ":" + fmt.Sprintf("%d", a.port), // Use the port from the Admin struct
-		Handler: r,
-	}
-
-	go func() {
-		<-ctx.Done()
-		slog.Info("admin shutting down")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err := srv.Shutdown(shutdownCtx); err != nil {
-			slog.Error("admin shutdown error", "err", err)
-		} else {
-			slog.Info("admin shutdown complete")
-		}
-	}()
+	return r
+}
 
-	slog.Info("admin API starting on :" + fmt.Sprintf("%d", a.port))
-	err := srv.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
+func (a *Admin) serve(ln net.Listener) {
+	slog.Info("admin API starting on " + ln.Addr().String())
+	if err := a.srv.Serve(ln); err != nil && err != http.ErrServerClosed {
 		slog.Error("admin API server error", "err", err)
 	}
-	return err
 }
 
-// Stop shuts down the Admin service
-func (a *Admin) Stop() {
-	// Implement shutdown logic here
+// Stop shuts down the Admin service, waiting for in-flight requests until ctx
+// ends.
+func (a *Admin) Stop(ctx context.Context) error {
+	if a.srv == nil {
+		return nil
+	}
+	slog.Info("admin shutting down")
+	if err := a.srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("admin: %w", err)
+	}
+	slog.Info("admin shutdown complete")
+	return nil
 }
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/data"
+	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
+	"github.com/Odyssey-Classic/server/internal/services/game"
+)
+
+type AdminSuite struct {
+	suite.Suite
+}
+
+func (s *AdminSuite) TestStartReturnsBindError() {
+	taken, err := net.Listen("tcp", ":0")
+	s.Require().NoError(err)
+	defer taken.Close()
+
+	root := data.NewOSRoot(s.T().TempDir())
+	mapStore, err := filestore.New(root.MapsDir())
+	s.Require().NoError(err)
+	a, err := New(taken.Addr().String(), root, mapStore, game.New(nil, nil, nil, nil, nil, game.Config{}))
+	s.Require().NoError(err)
+	wg := &sync.WaitGroup{}
+	err = a.Start(context.Background(), wg)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "admin:")
+	s.NoError(a.Stop(context.Background()))
+	wg.Wait()
+}
+
+func TestAdmin(t *testing.T) {
+	suite.Run(t, new(AdminSuite))
+}
@@ -0,0 +1,101 @@
+// Package administrators stores admin API accounts and decides what each may
+// do.
+package administrators
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// Role is an administrator's job, which decides the permissions they hold.
+type Role string
+
+const (
+	// RoleOwner may do everything, including managing other administrators.
+	RoleOwner Role = "owner"
+	// RoleMapper edits maps.
+	RoleMapper Role = "mapper"
+	// RoleModerator looks after players and sends announcements.
+	RoleModerator Role = "moderator"
+)
+
+// Permission names an action that is restricted to some roles.
+type Permission string
+
+const (
+	// PermEditMaps allows creating, changing and deleting maps.
+	PermEditMaps Permission = "maps:edit"
+	// PermAnnounce allows sending announcements to every player.
+	PermAnnounce Permission = "announcements:send"
+	// PermManageAdministrators allows managing admin accounts. Only owners
+	// hold it.
+	PermManageAdministrators Permission = "administrators:manage"
+	// PermViewAudit allows reading the audit log. Only owners hold it.
+	PermViewAudit Permission = "audit:view"
+)
+
+// rolePermissions lists what each role other than owner may do.
+var rolePermissions = map[Role][]Permission{
+	RoleMapper:    {PermEditMaps},
+	RoleModerator: {PermAnnounce},
+}
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok || r == RoleOwner
+}
+
+// Can reports whether the role holds perm. Owners hold every permission.
+func (r Role) Can(perm Permission) bool {
+	if r == RoleOwner {
+		return true
+	}
+	return slices.Contains(rolePermissions[r], perm)
+}
+
+var (
+	// ErrExists is returned when creating an administrator whose username is
+	// taken.
+	ErrExists = errors.New("username is taken")
+	// ErrNotFound is returned when no administrator matches.
+	ErrNotFound = errors.New("administrator not found")
+	// ErrInvalidRole is returned for roles that do not exist.
+	ErrInvalidRole = errors.New("role must be owner, mapper or moderator")
+	// ErrLastOwner is returned for changes that would leave no enabled owner.
+	ErrLastOwner = errors.New("at least one enabled owner must remain")
+)
+
+// Administrator is a login for the admin API.
+type Administrator struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash []byte    `json:"password_hash"`
+	Role         Role      `json:"role"`
+	Disabled     bool      `json:"disabled"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Store persists administrators. Usernames are unique, ignoring case.
+type Store interface {
+	// Create adds an administrator with the given credentials and role.
+	Create(username, password string, role Role) (*Administrator, error)
+
+	// Authenticate returns the administrator for username if password
+	// matches.
+	Authenticate(username, password string) (*Administrator, error)
+
+	// Get retrieves an administrator by ID.
+	Get(id string) (*Administrator, error)
+
+	// List returns every administrator, ordered by username.
+	List() ([]*Administrator, error)
+
+	// Update saves changes to an existing administrator. The username
+	// cannot change.
+	Update(a *Administrator) error
+
+	// Delete removes an administrator.
+	Delete(id string) error
+}
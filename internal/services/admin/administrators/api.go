@@ -0,0 +1,306 @@
+package administrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// CreateRequest is the body of POST /admin/administrators.
+type CreateRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
+
+// UpdateRequest is the body of PATCH /admin/administrators/{id}. Fields left
+// out are unchanged.
+type UpdateRequest struct {
+	Role     *Role `json:"role,omitempty"`
+	Disabled *bool `json:"disabled,omitempty"`
+}
+
+// PasswordRequest is the body of PUT /admin/administrators/{id}/password.
+type PasswordRequest struct {
+	Password string `json:"password"`
+}
+
+// API manages administrator accounts. Callers must hold
+// PermManageAdministrators.
+type API struct {
+	auth *Auth
+
+	// mu serialises changes so that two requests cannot both remove the
+	// last enabled owner.
+	mu sync.Mutex
+}
+
+// NewAPI returns the administrators API for the accounts and sessions auth
+// manages.
+func NewAPI(auth *Auth) *API {
+	return &API{auth: auth}
+}
+
+// Routes returns the chi router for administrators endpoints
+func (a *API) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", a.listAdministrators)
+	r.Post("/", a.createAdministrator)
+	r.Get("/{id}", a.getAdministrator)
+	r.Patch("/{id}", a.updateAdministrator)
+	r.Delete("/{id}", a.deleteAdministrator)
+	r.Put("/{id}/password", a.resetPassword)
+	r.Get("/{id}/sessions", a.listSessions)
+
+	return r
+}
+
+// record writes an audit entry for a change made to target by the actor in
+// ctx. See audit.Record for what happens when it returns false.
+func record(ctx context.Context, w http.ResponseWriter, recorder audit.Recorder, action string, target *Administrator, changes map[string]audit.Change, details map[string]any) bool {
+	return audit.Record(ctx, w, recorder, audit.Entry{
+		Action:     action,
+		TargetType: "administrator",
+		TargetID:   target.ID,
+		Changes:    changes,
+		Details:    details,
+	})
+}
+
+// target loads the administrator named in the URL, writing an error response
+// if it cannot.
+func (a *API) target(w http.ResponseWriter, r *http.Request) (*Administrator, bool) {
+	admin, err := a.auth.store.Get(chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, err.Error())
+		return nil, false
+	case err != nil:
+		slog.Error("loading administrator", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to load administrator")
+		return nil, false
+	}
+	return admin, true
+}
+
+// otherOwners reports whether an enabled owner other than id exists.
+func (a *API) otherOwners(id string) (bool, error) {
+	list, err := a.auth.store.List()
+	if err != nil {
+		return false, err
+	}
+	for _, admin := range list {
+		if admin.ID != id && admin.Role == RoleOwner && !admin.Disabled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkOwnerRemains refuses a change that would stop before from being an
+// enabled owner when no other enabled owner exists.
+func (a *API) checkOwnerRemains(w http.ResponseWriter, before *Administrator, after *Administrator) bool {
+	wasOwner := before.Role == RoleOwner && !before.Disabled
+	stillOwner := after != nil && after.Role == RoleOwner && !after.Disabled
+	if !wasOwner || stillOwner {
+		return true
+	}
+	others, err := a.otherOwners(before.ID)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to list administrators")
+		return false
+	}
+	if !others {
+		utils.WriteError(w, http.StatusConflict, ErrLastOwner.Error())
+		return false
+	}
+	return true
+}
+
+// listAdministrators handles GET /admin/administrators - List administrators
+func (a *API) listAdministrators(w http.ResponseWriter, r *http.Request) {
+	list, err := a.auth.store.List()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to list administrators")
+		return
+	}
+	resp := make([]AdministratorResponse, 0, len(list))
+	for _, admin := range list {
+		resp = append(resp, response(admin))
+	}
+	utils.WriteJSON(w, http.StatusOK, resp)
+}
+
+// createAdministrator handles POST /admin/administrators - Create an administrator
+func (a *API) createAdministrator(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	admin, err := a.auth.store.Create(req.Username, req.Password, req.Role)
+	switch {
+	case errors.Is(err, auth.ErrInvalidUsername), errors.Is(err, auth.ErrInvalidPassword), errors.Is(err, ErrInvalidRole):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, ErrExists):
+		utils.WriteError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		slog.Error("creating administrator", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create administrator")
+		return
+	}
+	if !record(r.Context(), w, a.auth.audit, "administrator.create", admin, nil, map[string]any{
+		"username": admin.Username,
+		"role":     admin.Role,
+	}) {
+		return
+	}
+	utils.WriteJSON(w, http.StatusCreated, response(admin))
+}
+
+// getAdministrator handles GET /admin/administrators/{id} - Get an administrator
+func (a *API) getAdministrator(w http.ResponseWriter, r *http.Request) {
+	admin, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, response(admin))
+}
+
+// updateAdministrator handles PATCH /admin/administrators/{id} - Change role or disable
+func (a *API) updateAdministrator(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if req.Role == nil && req.Disabled == nil {
+		utils.WriteError(w, http.StatusBadRequest, "Nothing to change")
+		return
+	}
+	if req.Role != nil && !req.Role.Valid() {
+		utils.WriteError(w, http.StatusBadRequest, ErrInvalidRole.Error())
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	before, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+	after := *before
+	if req.Role != nil {
+		after.Role = *req.Role
+	}
+	if req.Disabled != nil {
+		after.Disabled = *req.Disabled
+	}
+	changes := audit.Diff(response(before), response(&after))
+	if len(changes) == 0 {
+		utils.WriteJSON(w, http.StatusOK, response(before))
+		return
+	}
+	if !a.checkOwnerRemains(w, before, &after) {
+		return
+	}
+	if err := a.auth.store.Update(&after); err != nil {
+		slog.Error("updating administrator", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to update administrator")
+		return
+	}
+	var details map[string]any
+	if after.Disabled {
+		details = map[string]any{"sessions_revoked": a.auth.sessions.revokeAll(after.ID)}
+	}
+	if !record(r.Context(), w, a.auth.audit, "administrator.update", &after, changes, details) {
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, response(&after))
+}
+
+// deleteAdministrator handles DELETE /admin/administrators/{id} - Delete an administrator
+func (a *API) deleteAdministrator(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	admin, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+	if !a.checkOwnerRemains(w, admin, nil) {
+		return
+	}
+	if err := a.auth.store.Delete(admin.ID); err != nil {
+		slog.Error("deleting administrator", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete administrator")
+		return
+	}
+	revoked := a.auth.sessions.revokeAll(admin.ID)
+	if !record(r.Context(), w, a.auth.audit, "administrator.delete", admin, nil, map[string]any{
+		"username":         admin.Username,
+		"sessions_revoked": revoked,
+	}) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resetPassword handles PUT /admin/administrators/{id}/password - Set a new password
+func (a *API) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var req PasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to set password")
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	admin, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+	admin.PasswordHash = hash
+	if err := a.auth.store.Update(admin); err != nil {
+		slog.Error("resetting administrator password", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to set password")
+		return
+	}
+	// Whoever knew the old password is logged out.
+	revoked := a.auth.sessions.revokeAll(admin.ID)
+	if !record(r.Context(), w, a.auth.audit, "administrator.password_reset", admin, nil, map[string]any{
+		"sessions_revoked": revoked,
+	}) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listSessions handles GET /admin/administrators/{id}/sessions - List active sessions
+func (a *API) listSessions(w http.ResponseWriter, r *http.Request) {
+	admin, ok := a.target(w, r)
+	if !ok {
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, a.auth.sessions.active(admin.ID))
+}
@@ -0,0 +1,236 @@
+package administrators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+)
+
+// failingRecorder is an audit.Recorder whose writes always fail.
+type failingRecorder struct{}
+
+func (failingRecorder) Record(context.Context, audit.Entry) error {
+	return errors.New("disk full")
+}
+
+type APISuite struct {
+	suite.Suite
+	store  *FileStore
+	auth   *Auth
+	log    *audit.FileLog
+	router chi.Router
+	owner  *Administrator
+	token  string
+}
+
+func (s *APISuite) SetupTest() {
+	store, err := NewFileStore(s.T().TempDir())
+	s.Require().NoError(err)
+	s.store = store
+	s.owner, err = store.Create("owner", "password1", RoleOwner)
+	s.Require().NoError(err)
+	s.log, err = audit.NewFileLog(s.T().TempDir())
+	s.Require().NoError(err)
+	s.auth, err = NewAuth(store, s.log)
+	s.Require().NoError(err)
+
+	s.router = chi.NewRouter()
+	s.router.Post("/sessions", s.auth.Login)
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.auth.Authenticate)
+		r.With(s.auth.Require(PermManageAdministrators)).Mount("/administrators", NewAPI(s.auth).Routes())
+	})
+	s.token = s.login("owner", "password1")
+}
+
+func (s *APISuite) do(method, path, token string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		s.Require().NoError(json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *APISuite) login(username, password string) string {
+	w := s.do(http.MethodPost, "/sessions", "", Credentials{Username: username, Password: password})
+	if w.Code != http.StatusCreated {
+		return ""
+	}
+	var resp SessionResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	return resp.Token
+}
+
+func (s *APISuite) create(username string, role Role) AdministratorResponse {
+	w := s.do(http.MethodPost, "/administrators", s.token, CreateRequest{Username: username, Password: "password1", Role: role})
+	s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var resp AdministratorResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	return resp
+}
+
+// entries returns the audit log, newest first.
+func (s *APISuite) entries() []audit.Entry {
+	page, err := s.log.Query(audit.Filter{})
+	s.Require().NoError(err)
+	return page.Entries
+}
+
+// lastEntry returns the latest audit entry.
+func (s *APISuite) lastEntry() audit.Entry {
+	entries := s.entries()
+	s.Require().NotEmpty(entries)
+	return entries[0]
+}
+
+func (s *APISuite) TestCreateListGet() {
+	mapper := s.create("mapper", RoleMapper)
+	s.Equal(RoleMapper, mapper.Role)
+	entry := s.lastEntry()
+	s.Equal("administrator.create", entry.Action)
+	s.Equal(s.owner.ID, entry.ActorID)
+	s.Equal("owner", entry.Actor)
+	s.Equal("administrator", entry.TargetType)
+	s.Equal(mapper.ID, entry.TargetID)
+
+	w := s.do(http.MethodGet, "/administrators", s.token, nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), "password")
+	var list []AdministratorResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&list))
+	s.Require().Len(list, 2)
+	s.Equal("mapper", list[0].Username)
+
+	s.Equal(http.StatusOK, s.do(http.MethodGet, "/administrators/"+mapper.ID, s.token, nil).Code)
+	s.Equal(http.StatusNotFound, s.do(http.MethodGet, "/administrators/missing", s.token, nil).Code)
+}
+
+func (s *APISuite) TestCreateErrors() {
+	w := s.do(http.MethodPost, "/administrators", s.token, CreateRequest{Username: "janitor", Password: "password1", Role: "janitor"})
+	s.Equal(http.StatusBadRequest, w.Code)
+	w = s.do(http.MethodPost, "/administrators", s.token, CreateRequest{Username: "x", Password: "password1", Role: RoleMapper})
+	s.Equal(http.StatusBadRequest, w.Code)
+	w = s.do(http.MethodPost, "/administrators", s.token, CreateRequest{Username: "OWNER", Password: "password1", Role: RoleMapper})
+	s.Equal(http.StatusConflict, w.Code)
+	s.Empty(s.entries())
+}
+
+func (s *APISuite) TestAuditFailure() {
+	s.auth.audit = failingRecorder{}
+
+	w := s.do(http.MethodPost, "/administrators", s.token, CreateRequest{Username: "mapper", Password: "password1", Role: RoleMapper})
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *APISuite) TestOnlyOwnersManage() {
+	s.create("mapper", RoleMapper)
+	token := s.login("mapper", "password1")
+	s.Equal(http.StatusForbidden, s.do(http.MethodGet, "/administrators", token, nil).Code)
+}
+
+func (s *APISuite) TestChangeRole() {
+	mapper := s.create("mapper", RoleMapper)
+	role := RoleModerator
+	w := s.do(http.MethodPatch, "/administrators/"+mapper.ID, s.token, UpdateRequest{Role: &role})
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	got, err := s.store.Get(mapper.ID)
+	s.Require().NoError(err)
+	s.Equal(RoleModerator, got.Role)
+	entry := s.lastEntry()
+	s.Equal("administrator.update", entry.Action)
+	s.Equal(audit.Change{From: "mapper", To: "moderator"}, entry.Changes["role"])
+	s.NotContains(entry.Changes, "disabled")
+
+	bad := Role("janitor")
+	s.Equal(http.StatusBadRequest, s.do(http.MethodPatch, "/administrators/"+mapper.ID, s.token, UpdateRequest{Role: &bad}).Code)
+	s.Equal(http.StatusBadRequest, s.do(http.MethodPatch, "/administrators/"+mapper.ID, s.token, UpdateRequest{}).Code)
+}
+
+func (s *APISuite) TestDisableEndsSessions() {
+	mapper := s.create("mapper", RoleMapper)
+	s.Require().NotEmpty(s.login("mapper", "password1"))
+	s.Len(s.auth.sessions.active(mapper.ID), 1)
+
+	disabled := true
+	w := s.do(http.MethodPatch, "/administrators/"+mapper.ID, s.token, UpdateRequest{Disabled: &disabled})
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	s.Empty(s.auth.sessions.active(mapper.ID))
+	s.Empty(s.login("mapper", "password1"))
+	entry := s.lastEntry()
+	s.Equal(audit.Change{From: false, To: true}, entry.Changes["disabled"])
+	s.EqualValues(1, entry.Details["sessions_revoked"])
+}
+
+func (s *APISuite) TestLastOwnerKept() {
+	id := s.owner.ID
+	disabled := true
+	mapper := RoleMapper
+	s.Equal(http.StatusConflict, s.do(http.MethodPatch, "/administrators/"+id, s.token, UpdateRequest{Disabled: &disabled}).Code)
+	s.Equal(http.StatusConflict, s.do(http.MethodPatch, "/administrators/"+id, s.token, UpdateRequest{Role: &mapper}).Code)
+	s.Equal(http.StatusConflict, s.do(http.MethodDelete, "/administrators/"+id, s.token, nil).Code)
+
+	// With a second owner the first may step down.
+	s.create("owner2", RoleOwner)
+	s.Equal(http.StatusOK, s.do(http.MethodPatch, "/administrators/"+id, s.token, UpdateRequest{Role: &mapper}).Code)
+}
+
+func (s *APISuite) TestDelete() {
+	mapper := s.create("mapper", RoleMapper)
+	token := s.login("mapper", "password1")
+
+	s.Equal(http.StatusNoContent, s.do(http.MethodDelete, "/administrators/"+mapper.ID, s.token, nil).Code)
+	s.Equal("administrator.delete", s.lastEntry().Action)
+	_, err := s.store.Get(mapper.ID)
+	s.ErrorIs(err, ErrNotFound)
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, "/administrators", token, nil).Code)
+	s.Equal(http.StatusNotFound, s.do(http.MethodDelete, "/administrators/"+mapper.ID, s.token, nil).Code)
+}
+
+func (s *APISuite) TestResetPassword() {
+	mapper := s.create("mapper", RoleMapper)
+	s.Require().NotEmpty(s.login("mapper", "password1"))
+
+	path := "/administrators/" + mapper.ID + "/password"
+	s.Equal(http.StatusBadRequest, s.do(http.MethodPut, path, s.token, PasswordRequest{Password: "short"}).Code)
+	s.Equal(http.StatusNoContent, s.do(http.MethodPut, path, s.token, PasswordRequest{Password: "password2"}).Code)
+
+	s.Equal("administrator.password_reset", s.lastEntry().Action)
+	s.Empty(s.auth.sessions.active(mapper.ID))
+	s.Empty(s.login("mapper", "password1"))
+	s.NotEmpty(s.login("mapper", "password2"))
+}
+
+func (s *APISuite) TestListSessions() {
+	s.login("owner", "password1")
+
+	w := s.do(http.MethodGet, "/administrators/"+s.owner.ID+"/sessions", s.token, nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), s.token)
+	var list []SessionInfo
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&list))
+	s.Len(list, 2)
+	s.NotEmpty(list[0].ID)
+	s.NotEmpty(list[0].RemoteAddr)
+}
+
+func TestAPI(t *testing.T) {
+	suite.Run(t, new(APISuite))
+}
@@ -0,0 +1,244 @@
+package administrators
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// Credentials is the body of POST /admin/sessions.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// SetupRequest is the body of POST /admin/setup.
+type SetupRequest struct {
+	// Code is the one-time setup code written to the server log.
+	Code     string `json:"code"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// AdministratorResponse describes an administrator without their password
+// hash.
+type AdministratorResponse struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Role      Role      `json:"role"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SessionResponse carries the bearer token for a new admin session.
+type SessionResponse struct {
+	Token         string                `json:"token"`
+	ExpiresAt     time.Time             `json:"expires_at"`
+	Administrator AdministratorResponse `json:"administrator"`
+}
+
+func response(a *Administrator) AdministratorResponse {
+	return AdministratorResponse{
+		ID:        a.ID,
+		Username:  a.Username,
+		Role:      a.Role,
+		Disabled:  a.Disabled,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+type administratorKey struct{}
+
+// FromContext returns the administrator making the request, or nil outside
+// Authenticate.
+func FromContext(ctx context.Context) *Administrator {
+	a, _ := ctx.Value(administratorKey{}).(*Administrator)
+	return a
+}
+
+// Auth logs administrators in and guards the admin API.
+type Auth struct {
+	store    Store
+	sessions *sessions
+	audit    audit.Recorder
+
+	// setupMu guards setupCode, which is set only while no administrators
+	// exist.
+	setupMu   sync.Mutex
+	setupCode string
+}
+
+// NewAuth returns an Auth backed by store, recording the first owner's
+// creation to recorder. If the store is empty it creates a one-time setup
+// code, written to the log, that claims the first owner account through
+// Setup.
+func NewAuth(store Store, recorder audit.Recorder) (*Auth, error) {
+	a := &Auth{store: store, sessions: newSessions(SessionTTL), audit: recorder}
+	existing, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		if a.setupCode, err = randomHex(16); err != nil {
+			return nil, err
+		}
+		slog.Warn("no administrators exist; POST /admin/setup with this code to create the owner", "code", a.setupCode)
+	}
+	return a, nil
+}
+
+// Setup handles POST /admin/setup - Create the first owner account
+func (a *Auth) Setup(w http.ResponseWriter, r *http.Request) {
+	var req SetupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	a.setupMu.Lock()
+	defer a.setupMu.Unlock()
+	if a.setupCode == "" {
+		utils.WriteError(w, http.StatusConflict, "Setup is already complete")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(req.Code), []byte(a.setupCode)) != 1 {
+		utils.WriteError(w, http.StatusForbidden, "Wrong setup code")
+		return
+	}
+	owner, err := a.store.Create(req.Username, req.Password, RoleOwner)
+	switch {
+	case errors.Is(err, auth.ErrInvalidUsername), errors.Is(err, auth.ErrInvalidPassword):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		slog.Error("creating owner", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create owner")
+		return
+	}
+	a.setupCode = ""
+	slog.Info("owner created", "administrator", owner.ID, "username", owner.Username)
+	// Nobody is logged in yet, so the new owner is the actor.
+	ctx := audit.WithActor(r.Context(), owner.ID, owner.Username)
+	if !record(ctx, w, a.audit, "administrator.setup", owner, nil, nil) {
+		return
+	}
+	utils.WriteJSON(w, http.StatusCreated, response(owner))
+}
+
+// Login handles POST /admin/sessions - Exchange credentials for a session token
+func (a *Auth) Login(w http.ResponseWriter, r *http.Request) {
+	var req Credentials
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	admin, err := a.store.Authenticate(req.Username, req.Password)
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, auth.ErrWrongPassword):
+		utils.WriteError(w, http.StatusUnauthorized, "Invalid username or password")
+		return
+	case err != nil:
+		slog.Error("authenticating administrator", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+	if admin.Disabled {
+		utils.WriteError(w, http.StatusUnauthorized, "Account is disabled")
+		return
+	}
+	sess, err := a.sessions.create(admin.ID, r.RemoteAddr)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+	slog.Info("administrator logged in", "administrator", admin.ID, "username", admin.Username)
+	utils.WriteJSON(w, http.StatusCreated, SessionResponse{
+		Token:         sess.token,
+		ExpiresAt:     sess.expiresAt,
+		Administrator: response(admin),
+	})
+}
+
+// Logout handles DELETE /admin/sessions/current - End the caller's session
+func (a *Auth) Logout(w http.ResponseWriter, r *http.Request) {
+	token, _ := bearer(r)
+	a.sessions.revoke(token)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func bearer(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token, ok && token != ""
+}
+
+// Authenticate requires a live session token and stores its administrator in
+// the request context, where audit entries also find it.
+func (a *Auth) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearer(r)
+		if !ok {
+			utils.WriteError(w, http.StatusUnauthorized, "Missing bearer token")
+			return
+		}
+		sess, ok := a.sessions.lookup(token)
+		if !ok {
+			utils.WriteError(w, http.StatusUnauthorized, "Invalid or expired session")
+			return
+		}
+		admin, err := a.store.Get(sess.administratorID)
+		if errors.Is(err, ErrNotFound) || (err == nil && admin.Disabled) {
+			a.sessions.revoke(token)
+			utils.WriteError(w, http.StatusUnauthorized, "Invalid or expired session")
+			return
+		}
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to load administrator")
+			return
+		}
+		ctx := context.WithValue(r.Context(), administratorKey{}, admin)
+		ctx = audit.WithActor(ctx, admin.ID, admin.Username)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Require allows the request only if the administrator's role holds perm. It
+// must run after Authenticate.
+func (a *Auth) Require(perm Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			admin := FromContext(r.Context())
+			if admin == nil || !admin.Role.Can(perm) {
+				utils.WriteError(w, http.StatusForbidden, "Requires the "+string(perm)+" permission")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireForChanges is Require for requests that change something; every
+// administrator may read.
+func (a *Auth) RequireForChanges(perm Permission) func(http.Handler) http.Handler {
+	require := a.Require(perm)
+	return func(next http.Handler) http.Handler {
+		guarded := require(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+			default:
+				guarded.ServeHTTP(w, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package administrators
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+)
+
+type AuthSuite struct {
+	suite.Suite
+	store  *FileStore
+	auth   *Auth
+	router chi.Router
+}
+
+func (s *AuthSuite) SetupTest() {
+	store, err := NewFileStore(s.T().TempDir())
+	s.Require().NoError(err)
+	s.store = store
+	log, err := audit.NewFileLog(s.T().TempDir())
+	s.Require().NoError(err)
+	s.auth, err = NewAuth(store, log)
+	s.Require().NoError(err)
+
+	s.router = chi.NewRouter()
+	s.router.Post("/setup", s.auth.Setup)
+	s.router.Post("/sessions", s.auth.Login)
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.auth.Authenticate)
+		r.With(s.auth.RequireForChanges(PermEditMaps)).Post("/maps", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		r.With(s.auth.RequireForChanges(PermEditMaps)).Get("/maps", func(w http.ResponseWriter, r *http.Request) {
+			s.NotNil(FromContext(r.Context()))
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+}
+
+func (s *AuthSuite) do(method, path, token string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		s.Require().NoError(json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *AuthSuite) login(username string) string {
+	w := s.do(http.MethodPost, "/sessions", "", Credentials{Username: username, Password: "password1"})
+	s.Require().Equal(http.StatusCreated, w.Code)
+	var resp SessionResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	return resp.Token
+}
+
+func (s *AuthSuite) TestSetupCreatesFirstOwnerOnce() {
+	s.Require().NotEmpty(s.auth.setupCode)
+	setup := SetupRequest{Code: "wrong", Username: "owner", Password: "password1"}
+	s.Equal(http.StatusForbidden, s.do(http.MethodPost, "/setup", "", setup).Code)
+
+	setup.Code = s.auth.setupCode
+	setup.Password = "short"
+	s.Equal(http.StatusBadRequest, s.do(http.MethodPost, "/setup", "", setup).Code)
+
+	setup.Password = "password1"
+	w := s.do(http.MethodPost, "/setup", "", setup)
+	s.Require().Equal(http.StatusCreated, w.Code)
+	var owner AdministratorResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&owner))
+	s.Equal(RoleOwner, owner.Role)
+	s.NotContains(w.Body.String(), "password")
+
+	s.Equal(http.StatusConflict, s.do(http.MethodPost, "/setup", "", setup).Code)
+	s.NotEmpty(s.login("owner"))
+
+	page, err := s.auth.audit.(*audit.FileLog).Query(audit.Filter{})
+	s.Require().NoError(err)
+	s.Require().Len(page.Entries, 1)
+	s.Equal("administrator.setup", page.Entries[0].Action)
+	s.Equal(owner.ID, page.Entries[0].ActorID)
+	s.Equal("owner", page.Entries[0].Actor)
+}
+
+func (s *AuthSuite) TestNoSetupCodeOnceAdministratorsExist() {
+	_, err := s.store.Create("owner", "password1", RoleOwner)
+	s.Require().NoError(err)
+	auth, err := NewAuth(s.store, s.auth.audit)
+	s.Require().NoError(err)
+	s.Empty(auth.setupCode)
+}
+
+func (s *AuthSuite) TestPermissions() {
+	_, err := s.store.Create("moderator", "password1", RoleModerator)
+	s.Require().NoError(err)
+	token := s.login("moderator")
+
+	s.Equal(http.StatusNoContent, s.do(http.MethodGet, "/maps", token, nil).Code)
+	w := s.do(http.MethodPost, "/maps", token, nil)
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), string(PermEditMaps))
+}
+
+func (s *AuthSuite) TestExpiredSession() {
+	_, err := s.store.Create("mapper", "password1", RoleMapper)
+	s.Require().NoError(err)
+	token := s.login("mapper")
+	s.Equal(http.StatusNoContent, s.do(http.MethodPost, "/maps", token, nil).Code)
+
+	s.auth.sessions.now = func() time.Time { return time.Now().Add(SessionTTL) }
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodPost, "/maps", token, nil).Code)
+}
+
+func (s *AuthSuite) TestRoles() {
+	for _, perm := range []Permission{PermEditMaps, PermAnnounce} {
+		s.True(RoleOwner.Can(perm))
+	}
+	s.True(RoleMapper.Can(PermEditMaps))
+	s.False(RoleMapper.Can(PermAnnounce))
+	s.True(RoleModerator.Can(PermAnnounce))
+	s.False(RoleModerator.Can(PermEditMaps))
+	s.False(Role("janitor").Valid())
+	s.False(Role("janitor").Can(PermAnnounce))
+}
+
+func TestAuth(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
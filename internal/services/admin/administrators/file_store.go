@@ -0,0 +1,174 @@
+package administrators
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+)
+
+// FileStore persists administrators as JSON files named by ID. Usernames,
+// passwords and IDs follow the same rules as player accounts.
+type FileStore struct {
+	root string
+
+	mu         sync.RWMutex
+	byUsername map[string]string // lower case username -> ID
+}
+
+// NewFileStore opens the administrators stored under root, creating it if
+// needed.
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, err
+	}
+	s := &FileStore{root: root, byUsername: make(map[string]string)}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		var a Administrator
+		if err := data.ReadJSON(filepath.Join(root, e.Name()), &a); err != nil {
+			return nil, err
+		}
+		s.byUsername[strings.ToLower(a.Username)] = a.ID
+	}
+	return s, nil
+}
+
+func (s *FileStore) pathFor(id string) string {
+	return filepath.Join(s.root, id+".json")
+}
+
+func (s *FileStore) Create(username, password string, role Role) (*Administrator, error) {
+	if err := auth.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	if err := auth.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	id, err := auth.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(username)
+	if _, ok := s.byUsername[key]; ok {
+		return nil, ErrExists
+	}
+	a := &Administrator{
+		ID:           id,
+		Username:     username,
+		PasswordHash: hash,
+		Role:         role,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := data.WriteJSON(s.pathFor(id), a); err != nil {
+		return nil, err
+	}
+	s.byUsername[key] = id
+	return a, nil
+}
+
+func (s *FileStore) Authenticate(username, password string) (*Administrator, error) {
+	s.mu.RLock()
+	id, ok := s.byUsername[strings.ToLower(username)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	a, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.CheckPassword(a.PasswordHash, password); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *FileStore) Get(id string) (*Administrator, error) {
+	if !auth.ValidID(id) {
+		return nil, ErrNotFound
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(id)
+}
+
+func (s *FileStore) read(id string) (*Administrator, error) {
+	var a Administrator
+	if err := data.ReadJSON(s.pathFor(id), &a); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *FileStore) List() ([]*Administrator, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]*Administrator, 0, len(s.byUsername))
+	for _, id := range s.byUsername {
+		a, err := s.read(id)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].Username) < strings.ToLower(list[j].Username)
+	})
+	return list, nil
+}
+
+func (s *FileStore) Update(a *Administrator) error {
+	if !a.Role.Valid() {
+		return ErrInvalidRole
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := s.byUsername[strings.ToLower(a.Username)]; !ok || id != a.ID {
+		return ErrNotFound
+	}
+	return data.WriteJSON(s.pathFor(a.ID), a)
+}
+
+func (s *FileStore) Delete(id string) error {
+	if !auth.ValidID(id) {
+		return ErrNotFound
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, err := s.read(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.pathFor(id)); err != nil {
+		return err
+	}
+	delete(s.byUsername, strings.ToLower(a.Username))
+	return nil
+}
@@ -0,0 +1,71 @@
+package administrators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+)
+
+type FileStoreSuite struct {
+	suite.Suite
+	dir   string
+	store *FileStore
+}
+
+func (s *FileStoreSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	store, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	s.store = store
+}
+
+func (s *FileStoreSuite) TestCreateAndAuthenticate() {
+	a, err := s.store.Create("Mapper_1", "correct horse", RoleMapper)
+	s.Require().NoError(err)
+	s.Equal(RoleMapper, a.Role)
+	s.False(a.Disabled)
+
+	got, err := s.store.Authenticate("mapper_1", "correct horse")
+	s.Require().NoError(err)
+	s.Equal(a.ID, got.ID)
+
+	_, err = s.store.Authenticate("mapper_1", "wrong horse")
+	s.ErrorIs(err, auth.ErrWrongPassword)
+	_, err = s.store.Authenticate("nobody", "correct horse")
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *FileStoreSuite) TestValidation() {
+	_, err := s.store.Create("ab", "password1", RoleOwner)
+	s.ErrorIs(err, auth.ErrInvalidUsername)
+	_, err = s.store.Create("owner", "short", RoleOwner)
+	s.ErrorIs(err, auth.ErrInvalidPassword)
+	_, err = s.store.Create("owner", "password1", "janitor")
+	s.ErrorIs(err, ErrInvalidRole)
+
+	_, err = s.store.Create("owner", "password1", RoleOwner)
+	s.Require().NoError(err)
+	_, err = s.store.Create("OWNER", "password1", RoleMapper)
+	s.ErrorIs(err, ErrExists)
+}
+
+func (s *FileStoreSuite) TestListPersists() {
+	_, err := s.store.Create("zed", "password1", RoleModerator)
+	s.Require().NoError(err)
+	_, err = s.store.Create("Amy", "password1", RoleOwner)
+	s.Require().NoError(err)
+
+	reopened, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	list, err := reopened.List()
+	s.Require().NoError(err)
+	s.Require().Len(list, 2)
+	s.Equal("Amy", list[0].Username)
+	s.Equal("zed", list[1].Username)
+}
+
+func TestFileStore(t *testing.T) {
+	suite.Run(t, new(FileStoreSuite))
+}
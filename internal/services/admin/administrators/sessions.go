@@ -0,0 +1,147 @@
+package administrators
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SessionTTL is how long an admin login lasts.
+const SessionTTL = 12 * time.Hour
+
+// session is a logged in administrator. Sessions live in memory, so
+// restarting the server logs everyone out.
+type session struct {
+	token string
+	// id names the session in listings without revealing its token.
+	id              string
+	administratorID string
+	remoteAddr      string
+	createdAt       time.Time
+	lastSeen        time.Time
+	expiresAt       time.Time
+}
+
+// SessionInfo describes an active session without its token.
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	RemoteAddr string    `json:"remote_addr"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (s *session) info() SessionInfo {
+	return SessionInfo{
+		ID:         s.id,
+		RemoteAddr: s.remoteAddr,
+		CreatedAt:  s.createdAt,
+		LastSeenAt: s.lastSeen,
+		ExpiresAt:  s.expiresAt,
+	}
+}
+
+// sessions tracks the bearer tokens handed out at login.
+type sessions struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	byToken map[string]*session
+}
+
+func newSessions(ttl time.Duration) *sessions {
+	return &sessions{ttl: ttl, now: time.Now, byToken: make(map[string]*session)}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// create starts a session for the administrator.
+func (s *sessions) create(administratorID, remoteAddr string) (*session, error) {
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	sess := &session{
+		token:           token,
+		id:              id,
+		administratorID: administratorID,
+		remoteAddr:      remoteAddr,
+		createdAt:       now,
+		lastSeen:        now,
+		expiresAt:       now.Add(s.ttl),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byToken[sess.token] = sess
+	return sess, nil
+}
+
+// lookup returns the live session for token and marks it as used,
+// forgetting it if it has expired.
+func (s *sessions) lookup(token string) (*session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.byToken[token]
+	if !ok {
+		return nil, false
+	}
+	now := s.now()
+	if !now.Before(sess.expiresAt) {
+		delete(s.byToken, token)
+		return nil, false
+	}
+	sess.lastSeen = now
+	return sess, true
+}
+
+// revoke ends the session for token.
+func (s *sessions) revoke(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.byToken, token)
+}
+
+// revokeAll ends every session of the administrator and reports how many
+// there were.
+func (s *sessions) revokeAll(administratorID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for token, sess := range s.byToken {
+		if sess.administratorID == administratorID {
+			delete(s.byToken, token)
+			n++
+		}
+	}
+	return n
+}
+
+// active lists the administrator's live sessions, oldest first.
+func (s *sessions) active(administratorID string) []SessionInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	list := []SessionInfo{}
+	for _, sess := range s.byToken {
+		if sess.administratorID == administratorID && now.Before(sess.expiresAt) {
+			list = append(list, sess.info())
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
@@ -0,0 +1,70 @@
+package announcements
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/game"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// Announcer delivers a server-wide announcement to every player.
+type Announcer interface {
+	Announce(text string) error
+}
+
+// API represents the announcements admin API
+type API struct {
+	announcer Announcer
+	audit     audit.Recorder
+}
+
+// New returns an API that sends announcements through announcer, recording
+// each one to recorder.
+func New(announcer Announcer, recorder audit.Recorder) *API {
+	return &API{announcer: announcer, audit: recorder}
+}
+
+// Request is the body of POST /admin/announcements
+type Request struct {
+	Message string `json:"message"`
+}
+
+// Routes returns the chi router for announcement endpoints
+func (a *API) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Post("/", a.announce)
+	return r
+}
+
+// announce handles POST /admin/announcements - Broadcast a message to all players
+func (a *API) announce(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if err := a.announcer.Announce(req.Message); err != nil {
+		switch {
+		case errors.Is(err, game.ErrInvalidChat):
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, game.ErrBusy):
+			utils.WriteError(w, http.StatusServiceUnavailable, "Too many pending announcements, try again")
+		default:
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to send announcement")
+		}
+		return
+	}
+	if !audit.Record(r.Context(), w, a.audit, audit.Entry{
+		Action:     "announcement.send",
+		TargetType: "announcement",
+		Details:    map[string]any{"message": req.Message},
+	}) {
+		return
+	}
+	utils.WriteJSON(w, http.StatusAccepted, req)
+}
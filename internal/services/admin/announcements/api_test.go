@@ -0,0 +1,119 @@
+package announcements
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/game"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// recordingAnnouncer remembers announcements and fails with err when set.
+type recordingAnnouncer struct {
+	sent []string
+	err  error
+}
+
+func (r *recordingAnnouncer) Announce(text string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, text)
+	return nil
+}
+
+// failingRecorder is an audit.Recorder whose writes always fail.
+type failingRecorder struct{}
+
+func (failingRecorder) Record(context.Context, audit.Entry) error {
+	return errors.New("disk full")
+}
+
+type AnnouncementsAPITestSuite struct {
+	suite.Suite
+	announcer *recordingAnnouncer
+	log       *audit.FileLog
+	router    chi.Router
+}
+
+func (s *AnnouncementsAPITestSuite) SetupTest() {
+	s.announcer = &recordingAnnouncer{}
+	log, err := audit.NewFileLog(s.T().TempDir())
+	s.Require().NoError(err)
+	s.log = log
+	s.router = chi.NewRouter()
+	s.router.Mount("/admin/announcements", New(s.announcer, log).Routes())
+}
+
+func (s *AnnouncementsAPITestSuite) post(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/admin/announcements", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *AnnouncementsAPITestSuite) TestAnnounce() {
+	w := s.post(`{"message": "Server restarting in 5 minutes"}`)
+
+	s.Equal(http.StatusAccepted, w.Code)
+	s.Equal([]string{"Server restarting in 5 minutes"}, s.announcer.sent)
+
+	page, err := s.log.Query(audit.Filter{})
+	s.Require().NoError(err)
+	s.Require().Len(page.Entries, 1)
+	s.Equal("announcement.send", page.Entries[0].Action)
+	s.Equal("Server restarting in 5 minutes", page.Entries[0].Details["message"])
+}
+
+func (s *AnnouncementsAPITestSuite) TestInvalidJSON() {
+	w := s.post(`{`)
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Empty(s.announcer.sent)
+}
+
+func (s *AnnouncementsAPITestSuite) TestErrors() {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: message is empty", game.ErrInvalidChat), http.StatusBadRequest},
+		{game.ErrBusy, http.StatusServiceUnavailable},
+		{fmt.Errorf("boom"), http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		s.announcer.err = c.err
+		w := s.post(`{"message": ""}`)
+
+		s.Equal(c.code, w.Code, c.err.Error())
+		var resp utils.ErrorResponse
+		s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.Equal(c.code, resp.Code)
+	}
+	page, err := s.log.Query(audit.Filter{})
+	s.Require().NoError(err)
+	s.Empty(page.Entries)
+}
+
+func (s *AnnouncementsAPITestSuite) TestAuditFailure() {
+	s.router = chi.NewRouter()
+	s.router.Mount("/admin/announcements", New(s.announcer, failingRecorder{}).Routes())
+
+	w := s.post(`{"message": "hello"}`)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func TestAnnouncementsAPI(t *testing.T) {
+	suite.Run(t, new(AnnouncementsAPITestSuite))
+}
@@ -7,26 +7,56 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/Odyssey-Classic/server/internal/data"
+	"github.com/Odyssey-Classic/server/internal/services/admin/administrators"
+	"github.com/Odyssey-Classic/server/internal/services/admin/announcements"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
 	"github.com/Odyssey-Classic/server/internal/services/admin/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
 )
 
+// Game is the running game the admin API acts on.
+type Game interface {
+	announcements.Announcer
+	maps.Watcher
+}
+
 // API represents the main admin API structure
 type API struct {
-	router  chi.Router
-	mapsAPI *maps.API
+	router            chi.Router
+	auth              *administrators.Auth
+	administratorsAPI *administrators.API
+	mapsAPI           *maps.API
+	announcementsAPI  *announcements.API
+	auditAPI          *audit.API
 }
 
 // New creates a new Admin API instance
-func api(root data.Root) *API {
+func api(root data.Root, mapStore store.MapStore, game Game) (*API, error) {
+	admins, err := administrators.NewFileStore(root.AdminsDir())
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := audit.NewFileLog(root.AuditDir())
+	if err != nil {
+		return nil, err
+	}
+	auth, err := administrators.NewAuth(admins, auditLog)
+	if err != nil {
+		return nil, err
+	}
 	api := &API{
-		router:  chi.NewRouter(),
-		mapsAPI: maps.NewFileBacked(root.MapsDir()),
+		router:            chi.NewRouter(),
+		auth:              auth,
+		administratorsAPI: administrators.NewAPI(auth),
+		mapsAPI:           maps.NewWithStore(mapStore, auditLog, game),
+		announcementsAPI:  announcements.New(game, auditLog),
+		auditAPI:          audit.NewAPI(auditLog),
 	}
 
 	api.setupMiddleware()
 	api.setupRoutes()
 
-	return api
+	return api, nil
 }
 
 // setupMiddleware configures common middleware for the admin API
@@ -41,11 +71,25 @@ func (a *API) setupMiddleware() {
 // setupRoutes configures all API routes
 func (a *API) setupRoutes() {
 	a.router.Route("/admin", func(r chi.Router) {
-		// Mount maps API under /admin/maps
-		r.Mount("/maps", a.mapsAPI.Routes())
+		// Claiming the first owner and logging in need no session.
+		r.Post("/setup", a.auth.Setup)
+		r.Post("/sessions", a.auth.Login)
+
+		r.Group(func(r chi.Router) {
+			r.Use(a.auth.Authenticate)
+			r.Delete("/sessions/current", a.auth.Logout)
+
+			// Mount maps API under /admin/maps
+			r.With(a.auth.RequireForChanges(administrators.PermEditMaps)).Mount("/maps", a.mapsAPI.Routes())
+			// Mount announcements API under /admin/announcements
+			r.With(a.auth.Require(administrators.PermAnnounce)).Mount("/announcements", a.announcementsAPI.Routes())
+			// Mount administrators API under /admin/administrators
+			r.With(a.auth.Require(administrators.PermManageAdministrators)).Mount("/administrators", a.administratorsAPI.Routes())
+			// Mount audit API under /admin/audit
+			r.With(a.auth.Require(administrators.PermViewAudit)).Mount("/audit", a.auditAPI.Routes())
+		})
 
 		// Future admin endpoints can be added here
-		// r.Mount("/users", a.usersAPI.Routes())
 		// r.Mount("/settings", a.settingsAPI.Routes())
 	})
 }
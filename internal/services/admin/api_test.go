@@ -1,34 +1,76 @@
 package admin
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/Odyssey-Classic/server/internal/data"
+	"github.com/Odyssey-Classic/server/internal/services/admin/administrators"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
+	"github.com/Odyssey-Classic/server/internal/services/game"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
 	"github.com/stretchr/testify/suite"
 )
 
+const testPassword = "correct horse"
+
 // AdminAPITestSuite defines the test suite for Admin API integration tests
 type AdminAPITestSuite struct {
 	suite.Suite
 	api *API
+	// tokens holds a session token for an administrator of each role.
+	tokens map[administrators.Role]string
 }
 
 // SetupTest runs before each test method
 func (s *AdminAPITestSuite) SetupTest() {
 	// Use a per-test temporary data directory via data.Root abstraction
-	tmp := s.T().TempDir()
-	s.api = api(data.NewOSRoot(tmp))
+	root := data.NewOSRoot(s.T().TempDir())
+	admins, err := administrators.NewFileStore(root.AdminsDir())
+	s.Require().NoError(err)
+	roles := []administrators.Role{administrators.RoleOwner, administrators.RoleMapper, administrators.RoleModerator}
+	for _, role := range roles {
+		_, err := admins.Create(string(role), testPassword, role)
+		s.Require().NoError(err)
+	}
+
+	mapStore, err := filestore.New(root.MapsDir())
+	s.Require().NoError(err)
+	s.api, err = api(root, mapStore, game.New(nil, nil, nil, nil, nil, game.Config{}))
+	s.Require().NoError(err)
+
+	s.tokens = make(map[administrators.Role]string)
+	for _, role := range roles {
+		w := s.do(http.MethodPost, "/admin/sessions", "", administrators.Credentials{Username: string(role), Password: testPassword})
+		s.Require().Equal(http.StatusCreated, w.Code)
+		var resp administrators.SessionResponse
+		s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.tokens[role] = resp.Token
+	}
+}
+
+func (s *AdminAPITestSuite) do(method, path, token string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		s.Require().NoError(json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.api.ServeHTTP(w, req)
+	return w
 }
 
 // TestMiddlewareSetup tests that the API sets up middleware correctly
 func (s *AdminAPITestSuite) TestMiddlewareSetup() {
 	// Test with a valid route to see middleware working
-	req := httptest.NewRequest(http.MethodGet, "/admin/maps", nil)
-	w := httptest.NewRecorder()
-
-	s.api.ServeHTTP(w, req)
+	w := s.do(http.MethodGet, "/admin/maps", s.tokens[administrators.RoleOwner], nil)
 
 	// Check that Content-Type middleware is applied for valid requests
 	s.Equal("application/json", w.Header().Get("Content-Type"))
@@ -40,10 +82,7 @@ func (s *AdminAPITestSuite) TestMiddlewareSetup() {
 // TestRoutesSetup tests that routes are properly mounted
 func (s *AdminAPITestSuite) TestRoutesSetup() {
 	// Test that maps routes are mounted under /admin/maps
-	req := httptest.NewRequest(http.MethodGet, "/admin/maps", nil)
-	w := httptest.NewRecorder()
-
-	s.api.ServeHTTP(w, req)
+	w := s.do(http.MethodGet, "/admin/maps", s.tokens[administrators.RoleOwner], nil)
 
 	// Should get 200 OK (empty list) rather than 404, indicating route is mounted
 	s.Equal(http.StatusOK, w.Code)
@@ -74,6 +113,75 @@ func (s *AdminAPITestSuite) TestHTTPHandlerInterface() {
 	s.True(w.Code >= 200 && w.Code < 600, "Should return valid HTTP status code")
 }
 
+// TestRequiresSession tests that admin endpoints refuse anonymous requests
+func (s *AdminAPITestSuite) TestRequiresSession() {
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, "/admin/maps", "", nil).Code)
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, "/admin/maps", "not-a-session", nil).Code)
+}
+
+// TestLogin tests that wrong credentials are refused
+func (s *AdminAPITestSuite) TestLogin() {
+	w := s.do(http.MethodPost, "/admin/sessions", "", administrators.Credentials{Username: "owner", Password: "wrong password"})
+	s.Equal(http.StatusUnauthorized, w.Code)
+	w = s.do(http.MethodPost, "/admin/sessions", "", administrators.Credentials{Username: "nobody", Password: testPassword})
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+// TestLogout tests that a session stops working once ended
+func (s *AdminAPITestSuite) TestLogout() {
+	token := s.tokens[administrators.RoleMapper]
+	s.Equal(http.StatusNoContent, s.do(http.MethodDelete, "/admin/sessions/current", token, nil).Code)
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, "/admin/maps", token, nil).Code)
+}
+
+// TestRolePermissions tests that each role reaches only its own endpoints
+func (s *AdminAPITestSuite) TestRolePermissions() {
+	newMap := map[string]string{"name": "Forest", "last_updated": "2025-01-01T00:00:00Z"}
+	announcement := map[string]string{"message": "Restarting soon"}
+	cases := []struct {
+		role         administrators.Role
+		method, path string
+		body         any
+		want         int
+	}{
+		{administrators.RoleOwner, http.MethodPost, "/admin/maps", newMap, http.StatusCreated},
+		{administrators.RoleOwner, http.MethodPost, "/admin/announcements", announcement, http.StatusAccepted},
+		{administrators.RoleMapper, http.MethodPost, "/admin/maps", newMap, http.StatusCreated},
+		{administrators.RoleMapper, http.MethodPost, "/admin/announcements", announcement, http.StatusForbidden},
+		{administrators.RoleModerator, http.MethodGet, "/admin/maps", nil, http.StatusOK},
+		{administrators.RoleModerator, http.MethodPost, "/admin/maps", newMap, http.StatusForbidden},
+		{administrators.RoleModerator, http.MethodPost, "/admin/announcements", announcement, http.StatusAccepted},
+	}
+	for _, c := range cases {
+		w := s.do(c.method, c.path, s.tokens[c.role], c.body)
+		s.Equal(c.want, w.Code, "%s %s %s", c.role, c.method, c.path)
+		if c.want == http.StatusForbidden {
+			var resp utils.ErrorResponse
+			s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+			s.Equal(http.StatusForbidden, resp.Code)
+			s.NotEmpty(resp.Message)
+		}
+	}
+}
+
+// TestAuditRecordsChanges tests that changes are attributed to the
+// administrator who made them and that only owners read the audit log
+func (s *AdminAPITestSuite) TestAuditRecordsChanges() {
+	newMap := map[string]string{"name": "Forest", "last_updated": "2025-01-01T00:00:00Z"}
+	s.Require().Equal(http.StatusCreated, s.do(http.MethodPost, "/admin/maps", s.tokens[administrators.RoleMapper], newMap).Code)
+
+	s.Equal(http.StatusForbidden, s.do(http.MethodGet, "/admin/audit", s.tokens[administrators.RoleMapper], nil).Code)
+	w := s.do(http.MethodGet, "/admin/audit?actor=MAPPER&target_type=map", s.tokens[administrators.RoleOwner], nil)
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var page audit.Page
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&page))
+	s.Require().Len(page.Entries, 1)
+	s.Equal("map.create", page.Entries[0].Action)
+	s.Equal("mapper", page.Entries[0].Actor)
+	s.Equal("1", page.Entries[0].TargetID)
+	s.NotEmpty(page.Entries[0].RequestID)
+}
+
 // TestAdminAPI_Integration runs the complete test suite
 func TestAdminAPI_Integration(t *testing.T) {
 	suite.Run(t, new(AdminAPITestSuite))
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+const (
+	// DefaultLimit is the page size when a query names none.
+	DefaultLimit = 50
+	// MaxLimit is the largest page a query may ask for.
+	MaxLimit = 500
+)
+
+// Reader finds recorded entries.
+type Reader interface {
+	Query(f Filter) (Page, error)
+}
+
+// API serves the audit log.
+type API struct {
+	reader Reader
+}
+
+// NewAPI returns an API that reads entries from reader.
+func NewAPI(reader Reader) *API {
+	return &API{reader: reader}
+}
+
+// Routes returns the chi router for audit endpoints
+func (a *API) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/", a.listEntries)
+	return r
+}
+
+// listEntries handles GET /admin/audit - List audit entries, newest first
+func (a *API) listEntries(w http.ResponseWriter, r *http.Request) {
+	f, msg := parseFilter(r)
+	if msg != "" {
+		utils.WriteError(w, http.StatusBadRequest, msg)
+		return
+	}
+	page, err := a.reader.Query(f)
+	if err != nil {
+		slog.Error("querying audit log", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to read audit log")
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, page)
+}
+
+// parseFilter reads a Filter from the query string, returning a message
+// describing the first bad parameter.
+func parseFilter(r *http.Request) (Filter, string) {
+	q := r.URL.Query()
+	f := Filter{
+		Actor:      q.Get("actor"),
+		TargetType: q.Get("target_type"),
+		TargetID:   q.Get("target_id"),
+		Limit:      DefaultLimit,
+	}
+	times := []struct {
+		name string
+		dst  *time.Time
+	}{{"since", &f.Since}, {"until", &f.Until}}
+	for _, t := range times {
+		if v := q.Get(t.name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return f, t.name + " must be an RFC 3339 time"
+			}
+			*t.dst = parsed
+		}
+	}
+	if v := q.Get("before"); v != "" {
+		before, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || before == 0 {
+			return f, "before must be a positive sequence number"
+		}
+		f.Before = before
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return f, "limit must be between 1 and " + strconv.Itoa(MaxLimit)
+		}
+		f.Limit = limit
+	}
+	return f, ""
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type APISuite struct {
+	suite.Suite
+	log    *FileLog
+	router chi.Router
+}
+
+func (s *APISuite) SetupTest() {
+	log, err := NewFileLog(s.T().TempDir())
+	s.Require().NoError(err)
+	s.log = log
+	s.router = chi.NewRouter()
+	s.router.Mount("/admin/audit", NewAPI(log).Routes())
+}
+
+func (s *APISuite) get(query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit"+query, nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *APISuite) TestListFiltersAndPages() {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, actor := range []string{"owner", "mapper", "owner"} {
+		e := Entry{ActorID: actor, Actor: actor, Action: "map.update", TargetType: "map", TargetID: "1", Time: start.Add(time.Duration(i) * time.Hour)}
+		s.Require().NoError(s.log.Record(context.Background(), e))
+	}
+
+	w := s.get("?actor=owner&limit=1")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var page Page
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&page))
+	s.Require().Len(page.Entries, 1)
+	s.Equal(uint64(3), page.Entries[0].Seq)
+	s.Equal(uint64(3), page.Next)
+
+	w = s.get("?actor=owner&limit=1&before=3")
+	s.Require().Equal(http.StatusOK, w.Code)
+	page = Page{}
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&page))
+	s.Require().Len(page.Entries, 1)
+	s.Equal(uint64(1), page.Entries[0].Seq)
+	s.Zero(page.Next)
+
+	w = s.get("?since=2025-01-01T00:30:00Z&until=2025-01-01T01:30:00Z")
+	s.Require().Equal(http.StatusOK, w.Code)
+	page = Page{}
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&page))
+	s.Require().Len(page.Entries, 1)
+	s.Equal("mapper", page.Entries[0].Actor)
+}
+
+func (s *APISuite) TestBadParameters() {
+	for _, query := range []string{"?since=yesterday", "?until=1", "?before=0", "?before=x", "?limit=0", "?limit=501"} {
+		s.Equal(http.StatusBadRequest, s.get(query).Code, query)
+	}
+}
+
+func TestAPI(t *testing.T) {
+	suite.Run(t, new(APISuite))
+}
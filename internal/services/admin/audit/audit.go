@@ -0,0 +1,137 @@
+// Package audit records changes made through the admin API.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// Entry describes one change: who made it, what they did and to what.
+type Entry struct {
+	// Seq numbers entries in the order they were recorded, starting at 1.
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	// RequestID is the ID chi's RequestID middleware gave the request.
+	RequestID string `json:"request_id,omitempty"`
+	// ActorID and Actor identify the administrator who made the change.
+	ActorID string `json:"actor_id"`
+	Actor   string `json:"actor"`
+	// Action names the change, such as "map.update".
+	Action string `json:"action"`
+	// TargetType and TargetID identify what was changed, such as map 3.
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id,omitempty"`
+	// Changes summarises the fields that differ before and after.
+	Changes map[string]Change `json:"changes,omitempty"`
+	// Details holds other action specific values.
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Change is a field's value before and after. Values too large to keep in
+// the log, such as a map's tiles, are replaced by a note.
+type Change struct {
+	From any    `json:"from,omitempty"`
+	To   any    `json:"to,omitempty"`
+	Note string `json:"note,omitempty"`
+}
+
+// Recorder stores audit entries.
+type Recorder interface {
+	// Record stores e. Anything e leaves blank that ctx knows, such as the
+	// actor and request ID, is filled in, as is the time.
+	Record(ctx context.Context, e Entry) error
+}
+
+// Record writes e to recorder for a change a request has just made. The
+// change cannot be reported as a success without its entry, so when the entry
+// cannot be written Record logs the failure, answers 500 and returns false;
+// the caller must then stop without writing a response.
+func Record(ctx context.Context, w http.ResponseWriter, recorder Recorder, e Entry) bool {
+	if err := recorder.Record(ctx, e); err != nil {
+		slog.ErrorContext(ctx, "recording audit entry", "action", e.Action, "target_type", e.TargetType, "target", e.TargetID, "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "The change was made but could not be recorded in the audit log")
+		return false
+	}
+	return true
+}
+
+type actorKey struct{}
+
+type actor struct {
+	id, name string
+}
+
+// WithActor returns a context whose changes are attributed to the
+// administrator with the given ID and name.
+func WithActor(ctx context.Context, id, name string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor{id: id, name: name})
+}
+
+func actorFrom(ctx context.Context) (actor, bool) {
+	a, ok := ctx.Value(actorKey{}).(actor)
+	return a, ok
+}
+
+// maxChangeValue is the largest JSON encoding, in bytes, of a value Diff
+// keeps.
+const maxChangeValue = 256
+
+// Diff compares the JSON fields of before and after, which should be the
+// same type, and returns those that differ. Fields named in ignore, such as
+// timestamps that change on every write, are skipped.
+func Diff(before, after any, ignore ...string) map[string]Change {
+	from, to := fields(before), fields(after)
+	skip := make(map[string]bool, len(ignore))
+	for _, name := range ignore {
+		skip[name] = true
+	}
+
+	changes := make(map[string]Change)
+	for name := range union(from, to) {
+		if skip[name] || reflect.DeepEqual(from[name], to[name]) {
+			continue
+		}
+		if size(from[name]) > maxChangeValue || size(to[name]) > maxChangeValue {
+			changes[name] = Change{Note: "changed; too large to record"}
+			continue
+		}
+		changes[name] = Change{From: from[name], To: to[name]}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// fields decodes v's JSON encoding into its top level fields.
+func fields(v any) map[string]any {
+	m := map[string]any{}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return m
+	}
+	json.Unmarshal(b, &m)
+	return m
+}
+
+func union(a, b map[string]any) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+func size(v any) int {
+	b, _ := json.Marshal(v)
+	return len(b)
+}
@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+// failingRecorder is a Recorder whose writes always fail.
+type failingRecorder struct{}
+
+func (failingRecorder) Record(context.Context, Entry) error {
+	return errors.New("disk full")
+}
+
+type FileLogSuite struct {
+	suite.Suite
+	dir string
+	log *FileLog
+}
+
+func (s *FileLogSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	log, err := NewFileLog(s.dir)
+	s.Require().NoError(err)
+	s.log = log
+}
+
+// lines reads back the entries on disk.
+func (s *FileLogSuite) lines() []Entry {
+	f, err := os.Open(filepath.Join(s.dir, logFile))
+	s.Require().NoError(err)
+	defer f.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func (s *FileLogSuite) TestRecordAppends() {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.log.now = func() time.Time { return now }
+
+	ctx := WithActor(context.Background(), "a", "owner")
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, "host/1")
+	s.Require().NoError(s.log.Record(ctx, Entry{Action: "administrator.create", TargetType: "administrator", TargetID: "b"}))
+	reopened, err := NewFileLog(s.dir)
+	s.Require().NoError(err)
+	s.Require().NoError(reopened.Record(context.Background(), Entry{Action: "administrator.delete", Details: map[string]any{"username": "mapper"}}))
+
+	entries := s.lines()
+	s.Require().Len(entries, 2)
+	s.Equal(uint64(1), entries[0].Seq)
+	s.Equal(now, entries[0].Time)
+	s.Equal("a", entries[0].ActorID)
+	s.Equal("owner", entries[0].Actor)
+	s.Equal("host/1", entries[0].RequestID)
+	s.Equal(uint64(2), entries[1].Seq)
+	s.Equal("administrator.delete", entries[1].Action)
+	s.Equal("mapper", entries[1].Details["username"])
+}
+
+func (s *FileLogSuite) TestRecordHelper() {
+	w := httptest.NewRecorder()
+	s.True(Record(context.Background(), w, s.log, Entry{Action: "map.update", TargetType: "map"}))
+	s.Zero(w.Body.Len(), "a recorded change leaves the response to the caller")
+	s.Len(s.lines(), 1)
+
+	w = httptest.NewRecorder()
+	s.False(Record(context.Background(), w, failingRecorder{}, Entry{Action: "map.update", TargetType: "map"}))
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *FileLogSuite) TestQueryFilters() {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := func(actorID, actor, targetType, targetID string, minutes int) {
+		e := Entry{ActorID: actorID, Actor: actor, Action: "x", TargetType: targetType, TargetID: targetID, Time: start.Add(time.Duration(minutes) * time.Minute)}
+		s.Require().NoError(s.log.Record(context.Background(), e))
+	}
+	record("a", "Owner", "map", "1", 0)
+	record("b", "mapper", "map", "2", 10)
+	record("a", "Owner", "administrator", "b", 20)
+	record("b", "mapper", "map", "1", 30)
+
+	seqs := func(f Filter) []uint64 {
+		page, err := s.log.Query(f)
+		s.Require().NoError(err)
+		var got []uint64
+		for _, e := range page.Entries {
+			got = append(got, e.Seq)
+		}
+		return got
+	}
+	s.Equal([]uint64{4, 3, 2, 1}, seqs(Filter{}))
+	s.Equal([]uint64{3, 1}, seqs(Filter{Actor: "owner"}))
+	s.Equal([]uint64{4, 2}, seqs(Filter{Actor: "b"}))
+	s.Equal([]uint64{4, 1}, seqs(Filter{TargetType: "map", TargetID: "1"}))
+	s.Equal([]uint64{3, 2}, seqs(Filter{Since: start.Add(10 * time.Minute), Until: start.Add(30 * time.Minute)}))
+}
+
+func (s *FileLogSuite) TestQueryPages() {
+	for i := 0; i < 5; i++ {
+		s.Require().NoError(s.log.Record(context.Background(), Entry{Action: "x"}))
+	}
+
+	page, err := s.log.Query(Filter{Limit: 2})
+	s.Require().NoError(err)
+	s.Require().Len(page.Entries, 2)
+	s.Equal(uint64(5), page.Entries[0].Seq)
+	s.Equal(uint64(4), page.Next)
+
+	page, err = s.log.Query(Filter{Limit: 2, Before: page.Next})
+	s.Require().NoError(err)
+	s.Equal(uint64(3), page.Entries[0].Seq)
+	s.Equal(uint64(2), page.Next)
+
+	page, err = s.log.Query(Filter{Limit: 2, Before: page.Next})
+	s.Require().NoError(err)
+	s.Len(page.Entries, 1)
+	s.Zero(page.Next)
+}
+
+func (s *FileLogSuite) TestQueryEmpty() {
+	page, err := s.log.Query(Filter{})
+	s.Require().NoError(err)
+	s.NotNil(page.Entries)
+	s.Empty(page.Entries)
+}
+
+type DiffSuite struct {
+	suite.Suite
+}
+
+type sample struct {
+	Name    string `json:"name"`
+	Size    int    `json:"size"`
+	Tiles   []int  `json:"tiles"`
+	Updated string `json:"updated"`
+}
+
+func (s *DiffSuite) TestChangedFields() {
+	before := sample{Name: "Town", Size: 10, Updated: "monday"}
+	after := sample{Name: "City", Size: 10, Updated: "tuesday"}
+
+	changes := Diff(before, after, "updated")
+	s.Equal(map[string]Change{"name": {From: "Town", To: "City"}}, changes)
+}
+
+func (s *DiffSuite) TestLargeValues() {
+	before := sample{Tiles: make([]int, 200)}
+	after := sample{Tiles: make([]int, 200)}
+	after.Tiles[5] = 1
+
+	changes := Diff(before, after)
+	s.Require().Contains(changes, "tiles")
+	s.Nil(changes["tiles"].From)
+	s.Contains(changes["tiles"].Note, "too large")
+}
+
+func (s *DiffSuite) TestNoChanges() {
+	s.Nil(Diff(sample{Name: "Town"}, sample{Name: "Town"}))
+}
+
+func TestFileLog(t *testing.T) {
+	suite.Run(t, new(FileLogSuite))
+}
+
+func TestDiff(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
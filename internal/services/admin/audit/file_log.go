@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// logFile is the name of the audit log within its directory.
+const logFile = "audit.jsonl"
+
+// maxLine is the longest entry, in bytes, the log can read back.
+const maxLine = 1 << 20
+
+// Filter selects audit entries. Zero fields match everything.
+type Filter struct {
+	// Actor matches the actor's ID, or their name ignoring case.
+	Actor      string
+	TargetType string
+	TargetID   string
+	// Since and Until bound the entry time; Since is inclusive and Until
+	// exclusive.
+	Since time.Time
+	Until time.Time
+	// Before, when set, skips entries whose Seq is not below it. Pass a
+	// page's Next to fetch the following page.
+	Before uint64
+	// Limit caps the number of entries returned.
+	Limit int
+}
+
+func (f Filter) matches(e Entry) bool {
+	switch {
+	case f.Actor != "" && f.Actor != e.ActorID && !strings.EqualFold(f.Actor, e.Actor):
+		return false
+	case f.TargetType != "" && f.TargetType != e.TargetType:
+		return false
+	case f.TargetID != "" && f.TargetID != e.TargetID:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	case f.Before != 0 && e.Seq >= f.Before:
+		return false
+	}
+	return true
+}
+
+// Page is one page of query results, newest first.
+type Page struct {
+	Entries []Entry `json:"entries"`
+	// Next is the Filter.Before for the following page, or zero on the last
+	// page.
+	Next uint64 `json:"next,omitempty"`
+}
+
+// FileLog is an append-only audit log kept as one JSON entry per line.
+type FileLog struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.Mutex
+	lastSeq uint64
+}
+
+// NewFileLog returns the audit log kept in dir, creating dir if needed.
+func NewFileLog(dir string) (*FileLog, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	l := &FileLog{path: filepath.Join(dir, logFile), now: time.Now}
+	err := l.scan(func(e Entry) { l.lastSeq = e.Seq })
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *FileLog) Record(ctx context.Context, e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = l.now().UTC()
+	}
+	if e.RequestID == "" {
+		e.RequestID = middleware.GetReqID(ctx)
+	}
+	if a, ok := actorFrom(ctx); ok && e.ActorID == "" {
+		e.ActorID, e.Actor = a.id, a.name
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e.Seq = l.lastSeq + 1
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("audit: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("audit: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	l.lastSeq = e.Seq
+	return nil
+}
+
+// Query returns the newest entries matching f, up to f.Limit of them.
+func (l *FileLog) Query(f Filter) (Page, error) {
+	var matched []Entry
+	l.mu.Lock()
+	err := l.scan(func(e Entry) {
+		if f.matches(e) {
+			matched = append(matched, e)
+		}
+	})
+	l.mu.Unlock()
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Entries: []Entry{}}
+	for i := len(matched) - 1; i >= 0; i-- {
+		if f.Limit > 0 && len(page.Entries) == f.Limit {
+			page.Next = page.Entries[len(page.Entries)-1].Seq
+			break
+		}
+		page.Entries = append(page.Entries, matched[i])
+	}
+	return page, nil
+}
+
+// scan calls fn with each entry in the log, oldest first.
+func (l *FileLog) scan(fn func(Entry)) error {
+	f, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+	for line := 1; scanner.Scan(); line++ {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return fmt.Errorf("audit: %s line %d: %w", l.path, line, err)
+		}
+		fn(e)
+	}
+	return scanner.Err()
+}
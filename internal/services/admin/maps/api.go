@@ -1,37 +1,62 @@
 package maps
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 
 	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
 	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
 	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
-	"github.com/Odyssey-Classic/server/internal/services/admin/utils"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
 )
 
+// Watcher is told about every map saved over or deleted through the API, so a
+// running game can stop using its old copy. MapChanged must not block.
+type Watcher interface {
+	MapChanged(id int)
+}
+
 // API represents the maps admin API
 type API struct {
-	store store.MapStore
+	store   store.MapStore
+	audit   audit.Recorder
+	watcher Watcher
 }
 
-// NewFileBacked returns an API backed by the file store at root (default data/maps when empty).
-func NewFileBacked(root string) *API {
+// NewFileBacked returns an API backed by the file store at root (default data/maps when empty),
+// recording changes to recorder.
+func NewFileBacked(root string, recorder audit.Recorder) *API {
 	fs, err := filestore.New(root)
 	if err != nil {
 		panic(err)
 	}
-	return &API{store: fs}
+	return &API{store: fs, audit: recorder}
 }
 
-// NewWithStore allows injecting a custom store (useful for alternate backends).
-// Keep this constructor minimal and only if actually used elsewhere.
-func NewWithStore(s store.MapStore) *API { return &API{store: s} }
+// NewWithStore returns an API backed by s, recording changes to recorder and
+// telling watcher, when not nil, about changed maps. The server shares s
+// with the game.
+func NewWithStore(s store.MapStore, recorder audit.Recorder, watcher Watcher) *API {
+	return &API{store: s, audit: recorder, watcher: watcher}
+}
+
+// changed tells the watcher, if any, that map id was saved or deleted.
+func (a *API) changed(id int) {
+	if a.watcher != nil {
+		a.watcher.MapChanged(id)
+	}
+}
 
 // Routes returns the chi router for maps endpoints
 func (a *API) Routes() chi.Router {
@@ -42,10 +67,97 @@ func (a *API) Routes() chi.Router {
 	r.Get("/{id}", a.getMap)
 	r.Put("/{id}", a.updateMap)
 	r.Delete("/{id}", a.deleteMap)
+	r.Get("/{id}/revisions", a.listRevisions)
+	r.Get("/{id}/revisions/{version}", a.getRevision)
+	r.Post("/{id}/revisions/{version}/rollback", a.rollbackMap)
+	r.Get("/{id}/diff", a.diffMap)
+	r.Patch("/{id}/tiles", a.patchTiles)
+	r.Get("/{id}/tiles/{x}/{y}", a.getTile)
+	r.Put("/{id}/tiles/{x}/{y}", a.putTile)
 
 	return r
 }
 
+// record writes an audit entry for a change to map id. See audit.Record for
+// what happens when it returns false.
+func (a *API) record(ctx context.Context, w http.ResponseWriter, action string, id int, changes map[string]audit.Change, details map[string]any) bool {
+	return audit.Record(ctx, w, a.audit, audit.Entry{
+		Action:     action,
+		TargetType: "map",
+		TargetID:   strconv.Itoa(id),
+		Changes:    changes,
+		Details:    details,
+	})
+}
+
+// tilesChanged counts the tiles that differ between before and after.
+func tilesChanged(before, after *gamemaps.Map) int {
+	n := 0
+	for x := range before.Tiles {
+		for y := range before.Tiles[x] {
+			if !reflect.DeepEqual(before.Tiles[x][y], after.Tiles[x][y]) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// ValidationResponse is the body of a 422 response: the standard error
+// fields and every problem found in the map.
+type ValidationResponse struct {
+	utils.ErrorResponse
+	Errors []gamemaps.FieldError `json:"errors"`
+}
+
+// validate checks m on its own and against the maps in the store, writing a
+// 422 listing every problem if it is not fit to save.
+func (a *API) validate(w http.ResponseWriter, m *gamemaps.Map) bool {
+	var problems []gamemaps.FieldError
+	for _, err := range []error{m.Validate(), store.CheckReferences(a.store, m)} {
+		var verr gamemaps.ValidationError
+		if errors.As(err, &verr) {
+			problems = append(problems, verr...)
+		}
+	}
+	if len(problems) == 0 {
+		return true
+	}
+	utils.WriteJSON(w, http.StatusUnprocessableEntity, ValidationResponse{
+		ErrorResponse: utils.ErrorResponse{
+			Error:   http.StatusText(http.StatusUnprocessableEntity),
+			Code:    http.StatusUnprocessableEntity,
+			Message: "Map is invalid",
+		},
+		Errors: problems,
+	})
+	return false
+}
+
+// etag returns the entity tag of a map version.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// baseVersion returns the map version a write was based on: the If-Match
+// header when present, otherwise the version in the body. Zero means the
+// client sent neither.
+func baseVersion(r *http.Request, body int) (int, error) {
+	match := strings.TrimSpace(r.Header.Get("If-Match"))
+	if match == "" {
+		return body, nil
+	}
+	unquoted, ok := strings.CutPrefix(match, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	version, err := strconv.Atoi(unquoted)
+	if !ok || err != nil || version < 1 {
+		return 0, errors.New("If-Match must be an ETag returned by GET")
+	}
+	return version, nil
+}
+
 // listMaps handles GET /admin/maps - List all maps
 func (a *API) listMaps(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
@@ -60,23 +172,60 @@ func (a *API) listMaps(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// createMap handles POST /admin/maps - Create a new map
+// createMap handles POST /admin/maps - Create a new map holding the posted
+// map, or with ?clone={id} a copy of an existing map, renamed if the body
+// names it
 func (a *API) createMap(w http.ResponseWriter, r *http.Request) {
+	clone := r.URL.Query().Get("clone")
 	var mapData gamemaps.Map
-	if err := json.NewDecoder(r.Body).Decode(&mapData); err != nil {
+	err := json.NewDecoder(r.Body).Decode(&mapData)
+	if clone != "" && errors.Is(err, io.EOF) {
+		// A clone needs no body.
+		err = nil
+	}
+	if err != nil {
 		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
 		return
 	}
-	name := strings.TrimSpace(mapData.Name)
-	if name == "" {
-		utils.WriteError(w, http.StatusBadRequest, "Name is required")
+
+	template := &mapData
+	details := map[string]any{}
+	if clone != "" {
+		sourceID, err := strconv.Atoi(clone)
+		if err != nil || sourceID < 1 {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid map ID to clone")
+			return
+		}
+		source, ok := a.loadMap(w, sourceID)
+		if !ok {
+			return
+		}
+		if name := mapData.Name; name != "" {
+			source.Name = name
+		} else {
+			source.Name += " (copy)"
+		}
+		template = source
+		details["cloned_from"] = sourceID
+	}
+
+	template.Name = strings.TrimSpace(template.Name)
+	// The store assigns the ID. Validating with a posted or cloned one would
+	// let references to it pass as references to the map itself.
+	template.ID = 0
+	if !a.validate(w, template) {
 		return
 	}
-	m, err := a.store.Create(name)
+	m, err := a.store.CreateFrom(template)
 	if err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to create map")
 		return
 	}
+	details["name"] = m.Name
+	if !a.record(r.Context(), w, "map.create", m.ID, nil, details) {
+		return
+	}
+	w.Header().Set("ETag", etag(m.Version))
 	if err := utils.WriteJSON(w, http.StatusCreated, m); err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to encode response")
 		return
@@ -96,13 +245,16 @@ func (a *API) getMap(w http.ResponseWriter, r *http.Request) {
 		utils.WriteError(w, http.StatusNotFound, "Map not found")
 		return
 	}
+	w.Header().Set("ETag", etag(m.Version))
 	if err := utils.WriteJSON(w, http.StatusOK, m); err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to encode response")
 		return
 	}
 }
 
-// updateMap handles PUT /admin/maps/{id} - Update whole map
+// updateMap handles PUT /admin/maps/{id} - Update whole map. The client
+// names the version it edited, in the body or an If-Match header, and gets
+// 409 Conflict if someone saved the map since.
 func (a *API) updateMap(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
@@ -116,17 +268,54 @@ func (a *API) updateMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	base, err := baseVersion(r, mapData.Version)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if base == 0 {
+		utils.WriteError(w, http.StatusPreconditionRequired, "Send the version being edited in the body or an If-Match header")
+		return
+	}
+
+	before, ok := a.loadMap(w, id)
+	if !ok {
+		return
+	}
+	conflict := fmt.Sprintf("Map has changed since version %d; reload it and try again", base)
+	if before.Version != base {
+		utils.WriteError(w, http.StatusConflict, conflict)
+		return
+	}
+
 	// Preserve the original ID
 	mapData.ID = id
+	mapData.Version = base
+	if !a.validate(w, &mapData) {
+		return
+	}
 	if err := a.store.Update(&mapData); err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			utils.WriteError(w, http.StatusConflict, conflict)
+			return
+		}
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to update map")
 		return
 	}
+	a.changed(id)
+	if !a.record(r.Context(), w, "map.update", id, audit.Diff(before, &mapData, "last_updated", "version", "tiles"), map[string]any{
+		"version":       mapData.Version,
+		"tiles_changed": tilesChanged(before, &mapData),
+	}) {
+		return
+	}
 
 	response := map[string]interface{}{
 		"success":        true,
 		"updated_map_id": id,
+		"version":        mapData.Version,
 	}
+	w.Header().Set("ETag", etag(mapData.Version))
 
 	if err := utils.WriteJSON(w, http.StatusOK, response); err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "Failed to encode response")
@@ -142,8 +331,24 @@ func (a *API) deleteMap(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Loaded first so the audit log says what was deleted.
+	m, ok := a.loadMap(w, id)
+	if !ok {
+		return
+	}
 	if err := a.store.Delete(id); err != nil {
-		utils.WriteError(w, http.StatusNotFound, "Map not found")
+		if errors.Is(err, fs.ErrNotExist) {
+			utils.WriteError(w, http.StatusNotFound, "Map not found")
+			return
+		}
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete map")
+		return
+	}
+	a.changed(id)
+	if !a.record(r.Context(), w, "map.delete", id, nil, map[string]any{
+		"name":    m.Name,
+		"version": m.Version,
+	}) {
 		return
 	}
 
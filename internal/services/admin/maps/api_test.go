@@ -2,7 +2,9 @@ package maps
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -12,6 +14,10 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
+	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
 )
 
 // MapsAPITestSuite defines the test suite for Maps API tests
@@ -20,6 +26,7 @@ type MapsAPITestSuite struct {
 	api    *API
 	router chi.Router
 	dir    string
+	log    *audit.FileLog
 }
 
 // SetupTest runs before each test method
@@ -28,7 +35,9 @@ func (s *MapsAPITestSuite) SetupTest() {
 	d, err := os.MkdirTemp("", "maps-api-test-*")
 	s.Require().NoError(err)
 	s.dir = d
-	s.api = NewFileBacked(d)
+	s.log, err = audit.NewFileLog(s.T().TempDir())
+	s.Require().NoError(err)
+	s.api = NewFileBacked(d, s.log)
 	s.router = chi.NewRouter()
 	s.router.Mount("/admin/maps", s.api.Routes())
 }
@@ -138,8 +147,9 @@ func (s *MapsAPITestSuite) TestUpdateMap() {
 
 	// Now update it
 	updatedMap := gamemaps.Map{
-		Name: "Updated Test Map",
-		Tags: []string{"updated", "test"},
+		Name:    "Updated Test Map",
+		Tags:    []string{"updated", "test"},
+		Version: 1,
 	}
 
 	body, _ = json.Marshal(updatedMap)
@@ -158,6 +168,60 @@ func (s *MapsAPITestSuite) TestUpdateMap() {
 	success, ok := response["success"].(bool)
 	s.True(ok, "Response should contain success field")
 	s.True(success, "Expected success to be true")
+	s.EqualValues(2, response["version"])
+	s.Equal(`"2"`, w.Header().Get("ETag"))
+}
+
+// put sends m as the new contents of map id, with an If-Match header when
+// ifMatch is set
+func (s *MapsAPITestSuite) put(id string, m gamemaps.Map, ifMatch string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(m)
+	req := httptest.NewRequest(http.MethodPut, "/admin/maps/"+id, bytes.NewBuffer(body))
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestUpdateMap_StaleVersion tests that a write based on an old version is
+// rejected rather than overwriting someone else's change
+func (s *MapsAPITestSuite) TestUpdateMap_StaleVersion() {
+	created := s.createMap("Town")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/maps/1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+	s.Equal(`"1"`, etag)
+
+	first := *created
+	first.Name = "First"
+	s.Require().Equal(http.StatusOK, s.put("1", first, etag).Code)
+
+	second := *created
+	second.Name = "Second"
+	s.Equal(http.StatusConflict, s.put("1", second, etag).Code)
+	s.Equal(http.StatusConflict, s.put("1", second, "").Code)
+
+	got, err := s.api.store.Get(1)
+	s.Require().NoError(err)
+	s.Equal("First", got.Name)
+	s.Equal(2, got.Version)
+}
+
+// TestUpdateMap_Preconditions tests PUT requests without a usable version
+func (s *MapsAPITestSuite) TestUpdateMap_Preconditions() {
+	created := s.createMap("Town")
+
+	unversioned := *created
+	unversioned.Version = 0
+	s.Equal(http.StatusPreconditionRequired, s.put("1", unversioned, "").Code)
+	s.Equal(http.StatusBadRequest, s.put("1", unversioned, "1").Code)
+	s.Equal(http.StatusBadRequest, s.put("1", unversioned, `W/"1"`).Code)
+	s.Equal(http.StatusOK, s.put("1", unversioned, `"1"`).Code)
+	s.Equal(http.StatusNotFound, s.put("9", *created, "").Code)
 }
 
 // TestDeleteMap tests deleting an existing map
@@ -204,6 +268,404 @@ func (s *MapsAPITestSuite) TestDeleteMap_NotFound() {
 }
 
 // TestMapsAPI runs the complete test suite
+// createMap creates a map through the API and returns it
+func (s *MapsAPITestSuite) createMap(name string) *gamemaps.Map {
+	body, _ := json.Marshal(gamemaps.Map{Name: name})
+	req := httptest.NewRequest(http.MethodPost, "/admin/maps", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var m gamemaps.Map
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&m))
+	return &m
+}
+
+// TestChangesAreAudited tests that creating, updating and deleting a map
+// each leave an audit entry
+func (s *MapsAPITestSuite) TestChangesAreAudited() {
+	send := func(method, path string, m gamemaps.Map) {
+		body, _ := json.Marshal(m)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Less(w.Code, 300, w.Body.String())
+	}
+	send(http.MethodPost, "/admin/maps", gamemaps.Map{Name: "Town"})
+	updated := *gamemaps.NewMap(1, "City")
+	updated.Tiles[2][3].Trigger = "bell"
+	updated.Tiles[4][5].Passable = true
+	send(http.MethodPut, "/admin/maps/1", updated)
+	send(http.MethodDelete, "/admin/maps/1", gamemaps.Map{})
+
+	page, err := s.log.Query(audit.Filter{TargetType: "map", TargetID: "1"})
+	s.Require().NoError(err)
+	s.Require().Len(page.Entries, 3)
+	s.Equal("map.delete", page.Entries[0].Action)
+	s.Equal("City", page.Entries[0].Details["name"])
+	s.EqualValues(2, page.Entries[0].Details["version"])
+	s.Equal("map.create", page.Entries[2].Action)
+
+	update := page.Entries[1]
+	s.Equal("map.update", update.Action)
+	s.Equal(audit.Change{From: "Town", To: "City"}, update.Changes["name"])
+	s.NotContains(update.Changes, "last_updated")
+	s.EqualValues(2, update.Details["tiles_changed"])
+}
+
+// failingRecorder is an audit.Recorder whose writes always fail.
+type failingRecorder struct{}
+
+func (failingRecorder) Record(context.Context, audit.Entry) error {
+	return errors.New("disk full")
+}
+
+// TestAuditFailure tests that a change whose audit entry cannot be written
+// is not reported as a success
+func (s *MapsAPITestSuite) TestAuditFailure() {
+	created := s.createMap("Town")
+	s.api.audit = failingRecorder{}
+
+	updated := *created
+	updated.Name = "City"
+	s.Equal(http.StatusInternalServerError, s.put("1", updated, "").Code)
+	s.Equal(http.StatusInternalServerError, s.send(http.MethodPut, "/admin/maps/1/tiles/2/3", gamemaps.Tile{Passable: true}, "").Code)
+	s.Equal(http.StatusInternalServerError, s.send(http.MethodDelete, "/admin/maps/1", nil, "").Code)
+}
+
+// watchedMaps is a Watcher recording the IDs it is told about.
+type watchedMaps []int
+
+func (w *watchedMaps) MapChanged(id int) {
+	*w = append(*w, id)
+}
+
+// TestChangesAreWatched tests that every save over or deletion of a map is
+// reported to the watcher, and that refused edits are not
+func (s *MapsAPITestSuite) TestChangesAreWatched() {
+	fs, err := filestore.New(s.T().TempDir())
+	s.Require().NoError(err)
+	var watched watchedMaps
+	s.router = chi.NewRouter()
+	s.router.Mount("/admin/maps", NewWithStore(fs, s.log, &watched).Routes())
+
+	created := s.createMap("Town")
+	s.Empty(watched)
+
+	updated := *created
+	updated.Name = "City"
+	s.Require().Equal(http.StatusOK, s.put("1", updated, "").Code)
+	s.Require().Equal(http.StatusOK, s.send(http.MethodPut, "/admin/maps/1/tiles/2/3", gamemaps.Tile{Passable: true}, "").Code)
+	s.Require().Equal(http.StatusOK, s.send(http.MethodPatch, "/admin/maps/1/tiles", map[string]any{
+		"edits": []map[string]any{{"x": 4, "y": 5, "tile": gamemaps.Tile{Trigger: "bell"}}},
+	}, "").Code)
+	s.Require().Equal(http.StatusOK, s.send(http.MethodPost, "/admin/maps/1/revisions/1/rollback", nil, "").Code)
+	s.Equal(http.StatusConflict, s.put("1", updated, "").Code)
+	s.Require().Equal(http.StatusOK, s.send(http.MethodDelete, "/admin/maps/1", nil, "").Code)
+
+	s.Equal(watchedMaps{1, 1, 1, 1, 1}, watched)
+}
+
+// get sends a GET request to path
+func (s *MapsAPITestSuite) get(path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestRevisionsAndRollback tests listing and fetching past versions and
+// restoring one as a new version
+func (s *MapsAPITestSuite) TestRevisionsAndRollback() {
+	created := s.createMap("Town")
+	broken := *created
+	broken.Name = "Broken"
+	broken.Tiles[0][0].Trigger = "oops"
+	s.Require().Equal(http.StatusOK, s.put("1", broken, "").Code)
+
+	w := s.get("/admin/maps/1/revisions")
+	s.Require().Equal(http.StatusOK, w.Code)
+	var revs []store.Revision
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&revs))
+	s.Require().Len(revs, 2)
+	s.Equal(2, revs[0].Version)
+	s.Equal("Broken", revs[0].Name)
+
+	w = s.get("/admin/maps/1/revisions/1")
+	s.Require().Equal(http.StatusOK, w.Code)
+	var first gamemaps.Map
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&first))
+	s.Equal("Town", first.Name)
+	s.Equal(http.StatusNotFound, s.get("/admin/maps/1/revisions/9").Code)
+	s.Equal(http.StatusNotFound, s.get("/admin/maps/9/revisions").Code)
+
+	rollback := func(version, ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/maps/1/revisions/"+version+"/rollback", nil)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+	s.Equal(http.StatusConflict, rollback("1", `"1"`).Code)
+	s.Equal(http.StatusNotFound, rollback("9", "").Code)
+	w = rollback("1", `"2"`)
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	s.Equal(`"3"`, w.Header().Get("ETag"))
+
+	got, err := s.api.store.Get(1)
+	s.Require().NoError(err)
+	s.Equal("Town", got.Name)
+	s.Equal(3, got.Version)
+	s.Empty(got.Tiles[0][0].Trigger)
+
+	page, err := s.log.Query(audit.Filter{})
+	s.Require().NoError(err)
+	s.Equal("map.rollback", page.Entries[0].Action)
+	s.EqualValues(1, page.Entries[0].Details["restored_version"])
+}
+
+// TestDiffMap tests comparing two versions tile by tile
+func (s *MapsAPITestSuite) TestDiffMap() {
+	created := s.createMap("Town")
+	s.createMap("Hills")
+	edited := *created
+	edited.Tiles[2][3].AddGraphic(1, gamemaps.Graphic{GraphicID: 42})
+	edited.Links.North = 2
+	s.Require().Equal(http.StatusOK, s.put("1", edited, "").Code)
+
+	w := s.get("/admin/maps/1/diff?from=1")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var d gamemaps.Diff
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&d))
+	s.Equal(1, d.FromVersion)
+	s.Equal(2, d.ToVersion)
+	s.Equal([]gamemaps.LinkChange{{Direction: gamemaps.North, From: 0, To: 2}}, d.Links)
+	s.Require().Len(d.Tiles, 1)
+	s.Equal(2, d.Tiles[0].X)
+	s.Equal(3, d.Tiles[0].Y)
+	s.Equal(1, d.Tiles[0].Graphics[0].Layer)
+
+	w = s.get("/admin/maps/1/diff?from=2&to=1")
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Equal(http.StatusBadRequest, s.get("/admin/maps/1/diff").Code)
+	s.Equal(http.StatusBadRequest, s.get("/admin/maps/1/diff?from=1&to=x").Code)
+	s.Equal(http.StatusNotFound, s.get("/admin/maps/1/diff?from=7").Code)
+}
+
+// send sends body as JSON to path, with an If-Match header when ifMatch is
+// set
+func (s *MapsAPITestSuite) send(method, path string, body any, ifMatch string) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(b))
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestTile tests reading and replacing a single tile
+func (s *MapsAPITestSuite) TestTile() {
+	s.createMap("Town")
+
+	w := s.get("/admin/maps/1/tiles/3/4")
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Equal(`"1"`, w.Header().Get("ETag"))
+
+	tile := gamemaps.Tile{Passable: true, Trigger: "bell"}
+	w = s.send(http.MethodPut, "/admin/maps/1/tiles/3/4", tile, "")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	s.Equal(`"2"`, w.Header().Get("ETag"))
+	s.Equal(http.StatusConflict, s.send(http.MethodPut, "/admin/maps/1/tiles/3/4", tile, `"1"`).Code)
+
+	got, err := s.api.store.Get(1)
+	s.Require().NoError(err)
+	s.Equal(2, got.Version)
+	s.Equal("bell", got.Tiles[3][4].Trigger)
+
+	w = s.get("/admin/maps/1/tiles/3/4")
+	var read gamemaps.Tile
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&read))
+	s.True(read.Passable)
+}
+
+// TestTile_OutOfRange tests that bad coordinates are named in the error
+func (s *MapsAPITestSuite) TestTile_OutOfRange() {
+	s.createMap("Town")
+
+	for _, w := range []*httptest.ResponseRecorder{
+		s.get("/admin/maps/1/tiles/17/2"),
+		s.send(http.MethodPut, "/admin/maps/1/tiles/17/2", gamemaps.Tile{}, ""),
+	} {
+		s.Equal(http.StatusBadRequest, w.Code)
+		var resp utils.ErrorResponse
+		s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+		s.Contains(resp.Message, "(17, 2)")
+	}
+	s.Equal(http.StatusBadRequest, s.get("/admin/maps/1/tiles/a/2").Code)
+	s.Equal(http.StatusNotFound, s.get("/admin/maps/9/tiles/1/2").Code)
+}
+
+// TestPatchTiles tests that a batch of edits is saved as one version, or not
+// at all
+func (s *MapsAPITestSuite) TestPatchTiles() {
+	s.createMap("Town")
+
+	bad := TilesRequest{Edits: []TileEdit{
+		{X: 1, Y: 1, Tile: gamemaps.Tile{Passable: true}},
+		{X: 1, Y: -1, Tile: gamemaps.Tile{Passable: true}},
+	}}
+	w := s.send(http.MethodPatch, "/admin/maps/1/tiles", bad, "")
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "(1, -1)")
+	got, err := s.api.store.Get(1)
+	s.Require().NoError(err)
+	s.Equal(1, got.Version)
+	s.False(got.Tiles[1][1].Passable, "no edit is saved when one fails")
+
+	good := TilesRequest{Version: 1, Edits: []TileEdit{
+		{X: 1, Y: 1, Tile: gamemaps.Tile{Passable: true}},
+		{X: 2, Y: 1, Tile: gamemaps.Tile{Passable: true}},
+		{X: 3, Y: 1, Tile: gamemaps.Tile{}},
+	}}
+	w = s.send(http.MethodPatch, "/admin/maps/1/tiles", good, "")
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var resp TilesResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal(TilesResponse{Version: 2, TilesChanged: 2}, resp)
+
+	s.Equal(http.StatusConflict, s.send(http.MethodPatch, "/admin/maps/1/tiles", good, "").Code)
+	s.Equal(http.StatusBadRequest, s.send(http.MethodPatch, "/admin/maps/1/tiles", TilesRequest{}, "").Code)
+
+	got, err = s.api.store.Get(1)
+	s.Require().NoError(err)
+	s.Equal(2, got.Version)
+	s.True(got.Tiles[2][1].Passable)
+}
+
+// TestCreateMap_KeepsBody tests that everything posted is saved, while the
+// store assigns the ID, version and timestamp
+func (s *MapsAPITestSuite) TestCreateMap_KeepsBody() {
+	s.createMap("Sea")
+	posted := gamemaps.Map{
+		ID:         42,
+		Name:       "Harbor",
+		Tags:       []string{"coast"},
+		Attributes: map[string]string{"music": "waves"},
+		Version:    9,
+		Links:      gamemaps.MapLinks{East: 1},
+	}
+	posted.Tiles[4][4].Trigger = "dock"
+	w := s.send(http.MethodPost, "/admin/maps", posted, "")
+	s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+
+	got, err := s.api.store.Get(2)
+	s.Require().NoError(err)
+	s.Equal(2, got.ID)
+	s.Equal(1, got.Version)
+	s.False(got.LastUpdated.IsZero())
+	s.Equal([]string{"coast"}, got.Tags)
+	s.Equal("waves", got.Attributes["music"])
+	s.Equal(1, got.Links.East)
+	s.Equal("dock", got.Tiles[4][4].Trigger)
+}
+
+// TestCreateMap_Clone tests creating a map as a copy of another
+func (s *MapsAPITestSuite) TestCreateMap_Clone() {
+	source := gamemaps.Map{Name: "Town", Tags: []string{"safe"}}
+	source.Tiles[1][1].Passable = true
+	s.Require().Equal(http.StatusCreated, s.send(http.MethodPost, "/admin/maps", source, "").Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/maps?clone=1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var copied gamemaps.Map
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&copied))
+	s.Equal(2, copied.ID)
+	s.Equal("Town (copy)", copied.Name)
+	s.Equal([]string{"safe"}, copied.Tags)
+	s.True(copied.Tiles[1][1].Passable)
+
+	w = s.send(http.MethodPost, "/admin/maps?clone=1", map[string]string{"name": "Village"}, "")
+	s.Require().Equal(http.StatusCreated, w.Code)
+	renamed, err := s.api.store.Get(3)
+	s.Require().NoError(err)
+	s.Equal("Village", renamed.Name)
+
+	s.Equal(http.StatusNotFound, s.send(http.MethodPost, "/admin/maps?clone=9", nil, "").Code)
+	s.Equal(http.StatusBadRequest, s.send(http.MethodPost, "/admin/maps?clone=x", nil, "").Code)
+
+	page, err := s.log.Query(audit.Filter{TargetID: "2"})
+	s.Require().NoError(err)
+	s.Require().Len(page.Entries, 1)
+	s.EqualValues(1, page.Entries[0].Details["cloned_from"])
+}
+
+// TestValidation tests that maps with problems are refused with every
+// problem listed
+func (s *MapsAPITestSuite) TestValidation() {
+	created := s.createMap("Town")
+
+	bad := *created
+	bad.Links.West = 7
+	bad.Tiles[3][4].Warp = &gamemaps.WarpDestination{MapID: 9, X: 1, Y: 1}
+	bad.Tiles[5][5].BlockedDirections = []gamemaps.DirectionalBlock{{Direction: -1}}
+	w := s.put("1", bad, "")
+	s.Require().Equal(http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	var resp ValidationResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal(http.StatusUnprocessableEntity, resp.Code)
+	s.ElementsMatch([]string{
+		"links.west",
+		"tiles[3][4].warp.map_id",
+		"tiles[5][5].blocked_directions[0].direction",
+	}, fieldsOf(resp.Errors))
+
+	w = s.send(http.MethodPost, "/admin/maps", gamemaps.Map{Name: " "}, "")
+	s.Require().Equal(http.StatusUnprocessableEntity, w.Code)
+	resp = ValidationResponse{}
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal([]string{"name"}, fieldsOf(resp.Errors))
+
+	warp := gamemaps.Tile{Warp: &gamemaps.WarpDestination{MapID: 3}}
+	s.Equal(http.StatusUnprocessableEntity, s.send(http.MethodPut, "/admin/maps/1/tiles/0/0", warp, "").Code)
+	self := gamemaps.Tile{Warp: &gamemaps.WarpDestination{MapID: 1}}
+	s.Equal(http.StatusOK, s.send(http.MethodPut, "/admin/maps/1/tiles/0/0", self, "").Code)
+
+	got, err := s.api.store.Get(1)
+	s.Require().NoError(err)
+	s.Equal(2, got.Version, "only the valid edit was saved")
+}
+
+// TestCreateMap_PostedIDIsNotSelf tests that references to a posted ID are
+// checked, since the store assigns the new map another ID
+func (s *MapsAPITestSuite) TestCreateMap_PostedIDIsNotSelf() {
+	posted := gamemaps.NewMap(999, "Island")
+	posted.Links.North = 999
+	posted.Tiles[2][2].Warp = &gamemaps.WarpDestination{MapID: 999, X: 1, Y: 1}
+
+	w := s.send(http.MethodPost, "/admin/maps", posted, "")
+	s.Require().Equal(http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	var resp ValidationResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.ElementsMatch([]string{"links.north", "tiles[2][2].warp.map_id"}, fieldsOf(resp.Errors))
+
+	list, err := s.api.store.List("")
+	s.Require().NoError(err)
+	s.Empty(list)
+}
+
+func fieldsOf(errs []gamemaps.FieldError) []string {
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	return fields
+}
+
 func TestMapsAPI(t *testing.T) {
 	suite.Run(t, new(MapsAPITestSuite))
 }
@@ -0,0 +1,194 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// urlInt reads a positive integer URL parameter, writing a 400 naming what
+// if it is not one.
+func urlInt(w http.ResponseWriter, r *http.Request, name, what string) (int, bool) {
+	n, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil || n < 1 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid "+what)
+		return 0, false
+	}
+	return n, true
+}
+
+// loadMap reads map id, writing an error response if it cannot.
+func (a *API) loadMap(w http.ResponseWriter, id int) (*gamemaps.Map, bool) {
+	m, err := a.store.Get(id)
+	if errors.Is(err, fs.ErrNotExist) {
+		utils.WriteError(w, http.StatusNotFound, "Map not found")
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to load map")
+		return nil, false
+	}
+	return m, true
+}
+
+// loadRevision reads a saved version of map id, writing an error response if
+// it cannot.
+func (a *API) loadRevision(w http.ResponseWriter, id, version int) (*gamemaps.Map, bool) {
+	m, err := a.store.Revision(id, version)
+	if errors.Is(err, fs.ErrNotExist) {
+		utils.WriteError(w, http.StatusNotFound, fmt.Sprintf("Revision %d not found", version))
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to load revision")
+		return nil, false
+	}
+	return m, true
+}
+
+// listRevisions handles GET /admin/maps/{id}/revisions - List kept versions, newest first
+func (a *API) listRevisions(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	revs, err := a.store.Revisions(id)
+	if errors.Is(err, fs.ErrNotExist) {
+		utils.WriteError(w, http.StatusNotFound, "Map not found")
+		return
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to list revisions")
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, revs)
+}
+
+// getRevision handles GET /admin/maps/{id}/revisions/{version} - Get a map as saved at a version
+func (a *API) getRevision(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	version, ok := urlInt(w, r, "version", "version")
+	if !ok {
+		return
+	}
+	m, ok := a.loadRevision(w, id, version)
+	if !ok {
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, m)
+}
+
+// diffMap handles GET /admin/maps/{id}/diff?from=&to= - Compare two versions.
+// to defaults to the current version.
+func (a *API) diffMap(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	var versions [2]int
+	for i, name := range []string{"from", "to"} {
+		v := r.URL.Query().Get(name)
+		if v == "" && name == "to" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			utils.WriteError(w, http.StatusBadRequest, name+" must be a map version")
+			return
+		}
+		versions[i] = n
+	}
+
+	current, ok := a.loadMap(w, id)
+	if !ok {
+		return
+	}
+	load := func(version int) (*gamemaps.Map, bool) {
+		if version == 0 || version == current.Version {
+			return current, true
+		}
+		return a.loadRevision(w, id, version)
+	}
+	from, ok := load(versions[0])
+	if !ok {
+		return
+	}
+	to, ok := load(versions[1])
+	if !ok {
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, gamemaps.Compare(from, to))
+}
+
+// rollbackMap handles POST /admin/maps/{id}/revisions/{version}/rollback -
+// Save a past version again as the newest one. An If-Match header, when
+// sent, must name the current version.
+func (a *API) rollbackMap(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	version, ok := urlInt(w, r, "version", "version")
+	if !ok {
+		return
+	}
+	base, err := baseVersion(r, 0)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	current, ok := a.loadMap(w, id)
+	if !ok {
+		return
+	}
+	if base == 0 {
+		base = current.Version
+	}
+	conflict := fmt.Sprintf("Map has changed since version %d; reload it and try again", base)
+	if base != current.Version {
+		utils.WriteError(w, http.StatusConflict, conflict)
+		return
+	}
+	rev, ok := a.loadRevision(w, id, version)
+	if !ok {
+		return
+	}
+
+	restored := *rev
+	restored.ID = id
+	restored.Version = current.Version
+	if !a.validate(w, &restored) {
+		return
+	}
+	if err := a.store.Update(&restored); err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			utils.WriteError(w, http.StatusConflict, conflict)
+			return
+		}
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to roll back map")
+		return
+	}
+	a.changed(id)
+	if !a.record(r.Context(), w, "map.rollback", id, audit.Diff(current, &restored, "last_updated", "version", "tiles"), map[string]any{
+		"restored_version": version,
+		"version":          restored.Version,
+		"tiles_changed":    tilesChanged(current, &restored),
+	}) {
+		return
+	}
+	w.Header().Set("ETag", etag(restored.Version))
+	utils.WriteJSON(w, http.StatusOK, &restored)
+}
@@ -14,13 +14,24 @@ import (
 	"time"
 
 	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
 )
 
-// FileStore persists maps as JSON files under a root directory.
+// DefaultRevisionLimit is how many saved versions of each map are kept.
+const DefaultRevisionLimit = 50
+
+// revisionsDir is the directory under root holding each map's saved
+// versions, as revisions/000001/000003.json for version 3 of map 1.
+const revisionsDir = "revisions"
+
+// FileStore persists maps as JSON files under a root directory, keeping the
+// most recent saved versions of each map as revisions.
 type FileStore struct {
 	root   string
 	mu     sync.RWMutex
 	nextID int
+	// revisionLimit is how many revisions of each map are kept.
+	revisionLimit int
 }
 
 // New creates a FileStore pointing at the provided root directory.
@@ -33,7 +44,7 @@ func New(root string) (*FileStore, error) {
 		return nil, err
 	}
 
-	fs := &FileStore{root: root}
+	fs := &FileStore{root: root, revisionLimit: DefaultRevisionLimit}
 	// Initialize nextID by scanning existing files
 	maxID, err := fs.scanMaxID()
 	if err != nil {
@@ -72,29 +83,48 @@ func (s *FileStore) pathFor(id int) string {
 	return filepath.Join(s.root, fmt.Sprintf("%06d.json", id))
 }
 
+func (s *FileStore) revisionsFor(id int) string {
+	return filepath.Join(s.root, revisionsDir, fmt.Sprintf("%06d", id))
+}
+
+func (s *FileStore) revisionPath(id, version int) string {
+	return filepath.Join(s.revisionsFor(id), fmt.Sprintf("%06d.json", version))
+}
+
 // Create a new map with only a name.
 func (s *FileStore) Create(name string) (*gamemaps.Map, error) {
+	return s.CreateFrom(gamemaps.NewMap(0, name))
+}
+
+func (s *FileStore) CreateFrom(template *gamemaps.Map) (*gamemaps.Map, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	id := s.nextID
-	s.nextID++
-
-	m := gamemaps.NewMap(id, name)
-	// Ensure LastUpdated sensible
+	m := *template
+	m.ID = s.nextID
+	m.Version = 1
 	m.LastUpdated = time.Now()
-
-	if err := s.writeMap(m); err != nil {
+	if m.Tags == nil {
+		m.Tags = make([]string, 0)
+	}
+	if err := s.writeMap(&m); err != nil {
 		return nil, err
 	}
-	return m, nil
+	s.nextID++
+	return &m, nil
 }
 
 func (s *FileStore) Get(id int) (*gamemaps.Map, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.readMap(id)
+}
 
-	p := s.pathFor(id)
+func (s *FileStore) readMap(id int) (*gamemaps.Map, error) {
+	return s.readFile(s.pathFor(id))
+}
+
+func (s *FileStore) readFile(p string) (*gamemaps.Map, error) {
 	b, err := os.ReadFile(p)
 	if err != nil {
 		return nil, err
@@ -112,15 +142,98 @@ func (s *FileStore) Update(m *gamemaps.Map) error {
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	current, err := s.readMap(m.ID)
+	if err != nil {
+		return err
+	}
+	if m.Version != current.Version {
+		return store.ErrVersionConflict
+	}
+	m.Version++
 	m.LastUpdated = time.Now()
-	return s.writeMap(m)
+	if err := s.writeMap(m); err != nil {
+		m.Version--
+		return err
+	}
+	return nil
 }
 
 func (s *FileStore) Delete(id int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	p := s.pathFor(id)
-	return os.Remove(p)
+	if err := os.Remove(p); err != nil {
+		return err
+	}
+	// A later map may reuse the ID, so its history goes too.
+	return os.RemoveAll(s.revisionsFor(id))
+}
+
+func (s *FileStore) Revisions(id int) ([]store.Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, err := os.Stat(s.pathFor(id)); err != nil {
+		return nil, err
+	}
+	versions, err := s.revisionVersions(id)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]store.Revision, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		m, err := s.readFile(s.revisionPath(id, versions[i]))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, store.Revision{Version: m.Version, Name: m.Name, SavedAt: m.LastUpdated})
+	}
+	return out, nil
+}
+
+func (s *FileStore) Revision(id, version int) (*gamemaps.Map, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.readFile(s.revisionPath(id, version))
+}
+
+// revisionVersions returns the versions of map id kept as revisions, oldest
+// first.
+func (s *FileStore) revisionVersions(id int) ([]int, error) {
+	entries, err := os.ReadDir(s.revisionsFor(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var versions []int
+	for _, e := range entries {
+		base, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		if v, err := strconv.Atoi(base); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// pruneRevisions removes the oldest revisions of map id until at most keep
+// remain.
+func (s *FileStore) pruneRevisions(id, keep int) error {
+	versions, err := s.revisionVersions(id)
+	if err != nil {
+		return err
+	}
+	for len(versions) > keep {
+		if err := os.Remove(s.revisionPath(id, versions[0])); err != nil {
+			return err
+		}
+		versions = versions[1:]
+	}
+	return nil
 }
 
 func (s *FileStore) List(query string) ([]*gamemaps.Map, error) {
@@ -157,10 +270,24 @@ func (s *FileStore) List(query string) ([]*gamemaps.Map, error) {
 	return out, nil
 }
 
+// writeMap saves m, first keeping a copy as the revision for its version and
+// making room for it within the revision limit.
 func (s *FileStore) writeMap(m *gamemaps.Map) error {
-	p := s.pathFor(m.ID)
+	if err := os.MkdirAll(s.revisionsFor(m.ID), 0o755); err != nil {
+		return err
+	}
+	if err := s.pruneRevisions(m.ID, s.revisionLimit-1); err != nil {
+		return err
+	}
+	if err := writeFile(s.revisionPath(m.ID, m.Version), m); err != nil {
+		return err
+	}
+	return writeFile(s.pathFor(m.ID), m)
+}
+
+func writeFile(p string, m *gamemaps.Map) error {
 	// write to temp then rename for atomicity
-	tmp, err := os.CreateTemp(s.root, "*.tmp")
+	tmp, err := os.CreateTemp(filepath.Dir(p), "*.tmp")
 	if err != nil {
 		return err
 	}
@@ -1,10 +1,14 @@
 package file
 
 import (
+	"io/fs"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
 )
 
 type FileStoreSuite struct {
@@ -55,8 +59,30 @@ func (s *FileStoreSuite) TestUpdate() {
 	m, _ := s.fs.Create("Alpha")
 	m.Name = "Alpha Prime"
 	s.Require().NoError(s.fs.Update(m))
+	s.Equal(2, m.Version)
 	got, _ := s.fs.Get(m.ID)
 	s.Equal("Alpha Prime", got.Name)
+	s.Equal(2, got.Version)
+}
+
+func (s *FileStoreSuite) TestUpdateRejectsStaleVersion() {
+	m, _ := s.fs.Create("Alpha")
+	first, _ := s.fs.Get(m.ID)
+	second, _ := s.fs.Get(m.ID)
+
+	first.Name = "First"
+	s.Require().NoError(s.fs.Update(first))
+	second.Name = "Second"
+	s.ErrorIs(s.fs.Update(second), store.ErrVersionConflict)
+	s.Equal(1, second.Version)
+
+	got, _ := s.fs.Get(m.ID)
+	s.Equal("First", got.Name)
+}
+
+func (s *FileStoreSuite) TestUpdateMissing() {
+	m := gamemaps.NewMap(7, "Ghost")
+	s.ErrorIs(s.fs.Update(m), fs.ErrNotExist)
 }
 
 func (s *FileStoreSuite) TestDelete() {
@@ -66,6 +92,71 @@ func (s *FileStoreSuite) TestDelete() {
 	s.Error(err)
 }
 
+func (s *FileStoreSuite) TestCreateFrom() {
+	template := gamemaps.NewMap(99, "Template")
+	template.Version = 7
+	template.Attributes["music"] = "town"
+	template.Tiles[2][2].Trigger = "sign"
+
+	m, err := s.fs.CreateFrom(template)
+	s.Require().NoError(err)
+	s.Equal(1, m.ID)
+	s.Equal(1, m.Version)
+
+	got, err := s.fs.Get(m.ID)
+	s.Require().NoError(err)
+	s.Equal("Template", got.Name)
+	s.Equal("town", got.Attributes["music"])
+	s.Equal("sign", got.Tiles[2][2].Trigger)
+	s.Equal(99, template.ID, "the template is not changed")
+}
+
+func (s *FileStoreSuite) TestRevisions() {
+	m, _ := s.fs.Create("Alpha")
+	m.Name = "Beta"
+	s.Require().NoError(s.fs.Update(m))
+
+	revs, err := s.fs.Revisions(m.ID)
+	s.Require().NoError(err)
+	s.Require().Len(revs, 2)
+	s.Equal(2, revs[0].Version)
+	s.Equal("Beta", revs[0].Name)
+	s.Equal(1, revs[1].Version)
+
+	old, err := s.fs.Revision(m.ID, 1)
+	s.Require().NoError(err)
+	s.Equal("Alpha", old.Name)
+	_, err = s.fs.Revision(m.ID, 3)
+	s.ErrorIs(err, fs.ErrNotExist)
+
+	list, err := s.fs.List("")
+	s.Require().NoError(err)
+	s.Len(list, 1, "revisions are not listed as maps")
+}
+
+func (s *FileStoreSuite) TestRevisionLimit() {
+	s.fs.revisionLimit = 3
+	m, _ := s.fs.Create("Alpha")
+	for i := 0; i < 4; i++ {
+		s.Require().NoError(s.fs.Update(m))
+	}
+
+	revs, err := s.fs.Revisions(m.ID)
+	s.Require().NoError(err)
+	s.Require().Len(revs, 3)
+	s.Equal(5, revs[0].Version)
+	s.Equal(3, revs[2].Version)
+}
+
+func (s *FileStoreSuite) TestDeleteRemovesRevisions() {
+	m, _ := s.fs.Create("Alpha")
+	s.Require().NoError(s.fs.Delete(m.ID))
+	_, err := s.fs.Revisions(m.ID)
+	s.ErrorIs(err, fs.ErrNotExist)
+	_, err = os.Stat(s.fs.revisionsFor(m.ID))
+	s.ErrorIs(err, fs.ErrNotExist)
+}
+
 func TestFileStore(t *testing.T) {
 	suite.Run(t, new(FileStoreSuite))
 }
@@ -1,9 +1,23 @@
 package store
 
 import (
+	"errors"
+	"time"
+
 	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
 )
 
+// ErrVersionConflict is returned by Update when the map was changed after the
+// version the caller started from.
+var ErrVersionConflict = errors.New("map was changed since the version given")
+
+// Revision describes one saved version of a map.
+type Revision struct {
+	Version int       `json:"version"`
+	Name    string    `json:"name"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
 // MapStore abstracts persistence for game maps. Implementations should provide
 // durable storage semantics appropriate for the environment (e.g., file-based
 // JSON, database, etc.).
@@ -12,16 +26,39 @@ type MapStore interface {
 	// initialized Map (including assigned ID and timestamps).
 	Create(name string) (*gamemaps.Map, error)
 
+	// CreateFrom creates a new Map holding a copy of template's contents.
+	// The store assigns the ID, version and timestamp, ignoring template's.
+	CreateFrom(template *gamemaps.Map) (*gamemaps.Map, error)
+
 	// Get retrieves a Map by its ID.
 	Get(id int) (*gamemaps.Map, error)
 
-	// Update persists the provided Map (matching by ID).
+	// Update persists the provided Map (matching by ID). m.Version must be
+	// the stored version the caller started from; otherwise Update returns
+	// ErrVersionConflict and stores nothing. On success m.Version is
+	// incremented to the new stored version.
 	Update(m *gamemaps.Map) error
 
 	// Delete removes a Map by its ID.
 	Delete(id int) error
 
+	// Revisions lists the versions of a map that are still kept, newest
+	// first.
+	Revisions(id int) ([]Revision, error)
+
+	// Revision returns a map as it was saved at version.
+	Revision(id, version int) (*gamemaps.Map, error)
+
 	// List returns all maps, optionally filtered by a case-insensitive
 	// substring match on name when query is non-empty.
 	List(query string) ([]*gamemaps.Map, error)
 }
+
+// CheckReferences reports, as a gamemaps.ValidationError, links and warps in
+// m that name maps s does not hold.
+func CheckReferences(s MapStore, m *gamemaps.Map) error {
+	return m.CheckReferences(func(id int) bool {
+		_, err := s.Get(id)
+		return err == nil
+	})
+}
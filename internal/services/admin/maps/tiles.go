@@ -0,0 +1,199 @@
+package maps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	gamemaps "github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	"github.com/Odyssey-Classic/server/internal/services/admin/maps/store"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// editAttempts is how many times an unconditional tile edit is retried when
+// another save lands between reading and writing the map.
+const editAttempts = 3
+
+// TileEdit sets one tile.
+type TileEdit struct {
+	X    int           `json:"x"`
+	Y    int           `json:"y"`
+	Tile gamemaps.Tile `json:"tile"`
+}
+
+// TilesRequest is the body of PATCH /admin/maps/{id}/tiles.
+type TilesRequest struct {
+	// Version, like an If-Match header, makes the edits apply only to that
+	// version of the map.
+	Version int        `json:"version,omitempty"`
+	Edits   []TileEdit `json:"edits"`
+}
+
+// TilesResponse reports the map version saved by PATCH /admin/maps/{id}/tiles.
+type TilesResponse struct {
+	Version      int `json:"version"`
+	TilesChanged int `json:"tiles_changed"`
+}
+
+// tileCoords reads the x and y URL parameters.
+func tileCoords(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	x, errX := strconv.Atoi(chi.URLParam(r, "x"))
+	y, errY := strconv.Atoi(chi.URLParam(r, "y"))
+	if errX != nil || errY != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid tile coordinates")
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// editMap applies edit to map id and saves it as a new version, recording
+// the change. A non-zero base must be the current version. With no base the
+// edit applies to whatever is current, so it is retried if another save
+// races it. edit returning an error stops with a 400 carrying its message,
+// and an edited map that does not validate with a 422.
+func (a *API) editMap(w http.ResponseWriter, r *http.Request, id, base int, edit func(m *gamemaps.Map) error) (*gamemaps.Map, bool) {
+	for attempt := 1; ; attempt++ {
+		before, ok := a.loadMap(w, id)
+		if !ok {
+			return nil, false
+		}
+		version := base
+		if version == 0 {
+			version = before.Version
+		}
+		conflict := fmt.Sprintf("Map has changed since version %d; reload it and try again", version)
+		if before.Version != version {
+			utils.WriteError(w, http.StatusConflict, conflict)
+			return nil, false
+		}
+
+		after := *before
+		if err := edit(&after); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return nil, false
+		}
+		// SetTile counts every edit, but the store numbers saves.
+		after.Version = version
+		if !a.validate(w, &after) {
+			return nil, false
+		}
+		err := a.store.Update(&after)
+		if errors.Is(err, store.ErrVersionConflict) && base == 0 && attempt < editAttempts {
+			continue
+		}
+		if errors.Is(err, store.ErrVersionConflict) {
+			utils.WriteError(w, http.StatusConflict, conflict)
+			return nil, false
+		}
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to update map")
+			return nil, false
+		}
+		a.changed(id)
+		if !a.record(r.Context(), w, "map.update", id, audit.Diff(before, &after, "last_updated", "version", "tiles"), map[string]any{
+			"version":       after.Version,
+			"tiles_changed": tilesChanged(before, &after),
+		}) {
+			return nil, false
+		}
+		return &after, true
+	}
+}
+
+// getTile handles GET /admin/maps/{id}/tiles/{x}/{y} - Get one tile
+func (a *API) getTile(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	x, y, ok := tileCoords(w, r)
+	if !ok {
+		return
+	}
+	m, ok := a.loadMap(w, id)
+	if !ok {
+		return
+	}
+	tile, err := m.GetTile(x, y)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("ETag", etag(m.Version))
+	utils.WriteJSON(w, http.StatusOK, tile)
+}
+
+// putTile handles PUT /admin/maps/{id}/tiles/{x}/{y} - Replace one tile. An
+// If-Match header, when sent, must name the current version.
+func (a *API) putTile(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	x, y, ok := tileCoords(w, r)
+	if !ok {
+		return
+	}
+	var tile gamemaps.Tile
+	if err := json.NewDecoder(r.Body).Decode(&tile); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	base, err := baseVersion(r, 0)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	m, ok := a.editMap(w, r, id, base, func(m *gamemaps.Map) error {
+		return m.SetTile(x, y, tile)
+	})
+	if !ok {
+		return
+	}
+	w.Header().Set("ETag", etag(m.Version))
+	utils.WriteJSON(w, http.StatusOK, &m.Tiles[x][y])
+}
+
+// patchTiles handles PATCH /admin/maps/{id}/tiles - Set several tiles at
+// once. Either every edit is saved, in a single new version, or none is.
+func (a *API) patchTiles(w http.ResponseWriter, r *http.Request) {
+	id, ok := urlInt(w, r, "id", "map ID")
+	if !ok {
+		return
+	}
+	var req TilesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if len(req.Edits) == 0 {
+		utils.WriteError(w, http.StatusBadRequest, "No tile edits given")
+		return
+	}
+	base, err := baseVersion(r, req.Version)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	changed := 0
+	m, ok := a.editMap(w, r, id, base, func(m *gamemaps.Map) error {
+		before := m.Tiles
+		for i, e := range req.Edits {
+			if err := m.SetTile(e.X, e.Y, e.Tile); err != nil {
+				return fmt.Errorf("edit %d: %w", i, err)
+			}
+		}
+		changed = tilesChanged(&gamemaps.Map{Tiles: before}, m)
+		return nil
+	})
+	if !ok {
+		return
+	}
+	w.Header().Set("ETag", etag(m.Version))
+	utils.WriteJSON(w, http.StatusOK, TilesResponse{Version: m.Version, TilesChanged: changed})
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// ChatFilter screens player chat before it is delivered. It returns the text
+// to deliver, which may be rewritten, or an error to refuse the message. The
+// error text is shown to the sender.
+type ChatFilter interface {
+	Filter(from *Player, scope pb.ChatScope, text string) (string, error)
+}
+
+// GuildResolver tells the game which guild a character belongs to, returning
+// "" for none. It is called on the game loop for every online player a guild
+// message could reach, so it must answer from memory.
+type GuildResolver interface {
+	Guild(characterID string) (string, error)
+}
+
+var (
+	// ErrInvalidChat is returned for chat that is empty or too long.
+	ErrInvalidChat = errors.New("invalid chat message")
+	// ErrBusy is returned when the game cannot accept more work right now.
+	ErrBusy = errors.New("game is busy")
+)
+
+// announcementBuffer is how many announcements may wait for the next tick.
+const announcementBuffer = 16
+
+// Announce sends text to every player in the world. It is safe to call from
+// any goroutine; the announcement goes out on the next tick.
+func (g *Game) Announce(text string) error {
+	text, err := g.checkChat(text)
+	if err != nil {
+		return err
+	}
+	select {
+	case g.announcements <- text:
+		return nil
+	default:
+		return ErrBusy
+	}
+}
+
+// announce delivers an announcement to everyone.
+func (g *Game) announce(ctx context.Context, text string) {
+	slog.InfoContext(ctx, "announcement", "text", text)
+	g.send(g.world.everyone(), nil, message(&pb.Chat{Text: text, Scope: pb.ChatScope_CHAT_SCOPE_ANNOUNCEMENT}))
+}
+
+// checkChat trims text and checks it against the length limits.
+func (g *Game) checkChat(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("%w: message is empty", ErrInvalidChat)
+	}
+	if utf8.RuneCountInString(text) > g.cfg.Chat.MaxLength {
+		return "", fmt.Errorf("%w: message is longer than %d characters", ErrInvalidChat, g.cfg.Chat.MaxLength)
+	}
+	return text, nil
+}
+
+// chat delivers a message from p to the players its scope selects.
+func (g *Game) chat(ctx context.Context, p *Player, req *pb.Chat) {
+	refuse := func(code pb.ErrorCode, text string) {
+		g.queue(network.ToClient(p.client.ID(), errorMessage(code, text)))
+	}
+
+	text, err := g.checkChat(req.GetText())
+	if err != nil {
+		refuse(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, err.Error())
+		return
+	}
+	if !p.chatAllowance.allow(g.clock.Now(), g.cfg.Chat.Rate, g.cfg.Chat.Burst) {
+		refuse(pb.ErrorCode_ERROR_CODE_RATE_LIMITED, "sending messages too quickly")
+		return
+	}
+
+	scope := req.GetScope()
+	if scope == pb.ChatScope_CHAT_SCOPE_UNSPECIFIED {
+		scope = pb.ChatScope_CHAT_SCOPE_MAP
+	}
+
+	var to []*Player
+	var target string
+	switch scope {
+	case pb.ChatScope_CHAT_SCOPE_MAP:
+		to = g.world.audience(p.location.MapID)
+	case pb.ChatScope_CHAT_SCOPE_WHISPER:
+		recipient := g.world.playerNamed(req.GetTarget())
+		if recipient == nil {
+			refuse(pb.ErrorCode_ERROR_CODE_NOT_FOUND, fmt.Sprintf("%q is not online", req.GetTarget()))
+			return
+		}
+		target = recipient.name
+		to = []*Player{p}
+		if recipient != p {
+			to = append(to, recipient)
+		}
+	case pb.ChatScope_CHAT_SCOPE_GUILD:
+		guilds := g.cfg.Chat.Guilds
+		if guilds == nil {
+			refuse(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, "guild chat is not available")
+			return
+		}
+		guild, err := guilds.Guild(p.characterID)
+		if err != nil {
+			slog.ErrorContext(ctx, "resolving guild", "character", p.characterID, "error", err)
+			refuse(pb.ErrorCode_ERROR_CODE_INTERNAL, "could not look up your guild")
+			return
+		}
+		if guild == "" {
+			refuse(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, "you are not in a guild")
+			return
+		}
+		to = g.guildMembers(ctx, guilds, guild)
+	case pb.ChatScope_CHAT_SCOPE_ANNOUNCEMENT, pb.ChatScope_CHAT_SCOPE_SYSTEM:
+		refuse(pb.ErrorCode_ERROR_CODE_UNAUTHORIZED, "players cannot send to this scope")
+		return
+	default:
+		refuse(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, "unknown chat scope")
+		return
+	}
+
+	if f := g.cfg.Chat.Filter; f != nil {
+		if text, err = f.Filter(p, scope, text); err != nil {
+			refuse(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, err.Error())
+			return
+		}
+	}
+
+	slog.DebugContext(ctx, "chat", "character", p.characterID, "scope", scope, "target", target)
+	g.send(to, nil, message(&pb.Chat{
+		EntityId:   p.entityID,
+		SenderName: p.name,
+		Text:       text,
+		Scope:      scope,
+		Target:     target,
+	}))
+}
+
+// guildMembers returns the online players in guild. Players whose guild cannot
+// be resolved are left out.
+func (g *Game) guildMembers(ctx context.Context, guilds GuildResolver, guild string) []*Player {
+	var members []*Player
+	for _, p := range g.world.everyone() {
+		got, err := guilds.Guild(p.characterID)
+		if err != nil {
+			slog.WarnContext(ctx, "resolving guild", "character", p.characterID, "error", err)
+			continue
+		}
+		if got == guild {
+			members = append(members, p)
+		}
+	}
+	return members
+}
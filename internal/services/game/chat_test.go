@@ -0,0 +1,199 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// shoutFilter upper cases chat and refuses anything containing "spam".
+type shoutFilter struct{}
+
+func (shoutFilter) Filter(_ *Player, _ pb.ChatScope, text string) (string, error) {
+	if strings.Contains(text, "spam") {
+		return "", errors.New("no spam")
+	}
+	return strings.ToUpper(text), nil
+}
+
+// guildMap is a GuildResolver assigning characters to guilds by ID.
+type guildMap map[string]string
+
+func (g guildMap) Guild(characterID string) (string, error) {
+	return g[characterID], nil
+}
+
+type ChatSuite struct {
+	suite.Suite
+	maps  memoryMaps
+	out   chan network.Outbound
+	clock *fakeClock
+	game  *Game
+	// hero and sidekick share map 1; stranger is on map 2.
+	hero, sidekick, stranger *Player
+}
+
+func (s *ChatSuite) SetupTest() {
+	s.start(Config{})
+}
+
+func (s *ChatSuite) start(cfg Config) {
+	s.maps = memoryMaps{1: openMap(1), 2: openMap(2)}
+	s.out = make(chan network.Outbound, 100)
+	s.clock = &fakeClock{now: time.Unix(0, 0)}
+	cfg.Spawn = Location{MapID: 1, X: 8, Y: 8}
+	s.game = New(nil, s.out, s.maps, nil, nil, cfg)
+	s.game.clock = s.clock
+
+	s.run(network.ClientConnected{Client: newClient(1, "hero")})
+	s.run(network.ClientConnected{Client: newClient(2, "sidekick")})
+	s.run(network.ClientConnected{Client: newClient(3, "stranger")})
+	s.hero = s.game.world.byCharacter["hero"]
+	s.sidekick = s.game.world.byCharacter["sidekick"]
+	s.stranger = s.game.world.byCharacter["stranger"]
+	s.game.world.place(s.stranger, Location{MapID: 2, X: 8, Y: 8})
+}
+
+func (s *ChatSuite) run(events ...network.Event) []network.Outbound {
+	s.game.inputs = append(s.game.inputs, events...)
+	s.game.tick(context.Background())
+	var sent []network.Outbound
+	for len(s.out) > 0 {
+		sent = append(sent, <-s.out)
+	}
+	return sent
+}
+
+func (s *ChatSuite) say(p *Player, chat *pb.Chat) []network.Outbound {
+	return s.run(network.ClientMessage{Client: p.client, Message: message(chat)})
+}
+
+// requireRefused checks the only reply is an error of the given code.
+func (s *ChatSuite) requireRefused(sent []network.Outbound, code pb.ErrorCode) {
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1}, sent[0].To)
+	s.Equal(code, sent[0].Message.GetError().GetCode())
+}
+
+func (s *ChatSuite) TestMapChat() {
+	sent := s.say(s.hero, &pb.Chat{Text: "  hello  "})
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1, 2}, sent[0].To)
+	chat := sent[0].Message.GetChat()
+	s.Equal("hello", chat.Text)
+	s.Equal("hero", chat.SenderName)
+	s.Equal(s.hero.entityID, chat.EntityId)
+	s.Equal(pb.ChatScope_CHAT_SCOPE_MAP, chat.Scope)
+}
+
+func (s *ChatSuite) TestWhisper() {
+	sent := s.say(s.hero, &pb.Chat{Text: "psst", Scope: pb.ChatScope_CHAT_SCOPE_WHISPER, Target: "STRANGER"})
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1, 3}, sent[0].To)
+	s.Equal("stranger", sent[0].Message.GetChat().Target)
+}
+
+func (s *ChatSuite) TestWhisperToNobody() {
+	sent := s.say(s.hero, &pb.Chat{Text: "psst", Scope: pb.ChatScope_CHAT_SCOPE_WHISPER, Target: "ghost"})
+	s.requireRefused(sent, pb.ErrorCode_ERROR_CODE_NOT_FOUND)
+}
+
+func (s *ChatSuite) TestGuildNotAvailable() {
+	sent := s.say(s.hero, &pb.Chat{Text: "rally", Scope: pb.ChatScope_CHAT_SCOPE_GUILD})
+	s.requireRefused(sent, pb.ErrorCode_ERROR_CODE_BAD_REQUEST)
+}
+
+func (s *ChatSuite) TestGuild() {
+	// Guild members hear each other on any map; others do not.
+	s.start(Config{Chat: ChatConfig{Guilds: guildMap{"hero": "wardens", "stranger": "wardens", "sidekick": "rogues"}}})
+
+	sent := s.say(s.hero, &pb.Chat{Text: "rally", Scope: pb.ChatScope_CHAT_SCOPE_GUILD})
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1, 3}, sent[0].To)
+	chat := sent[0].Message.GetChat()
+	s.Equal("rally", chat.Text)
+	s.Equal(pb.ChatScope_CHAT_SCOPE_GUILD, chat.Scope)
+}
+
+func (s *ChatSuite) TestGuildless() {
+	s.start(Config{Chat: ChatConfig{Guilds: guildMap{"stranger": "wardens"}}})
+
+	sent := s.say(s.hero, &pb.Chat{Text: "rally", Scope: pb.ChatScope_CHAT_SCOPE_GUILD})
+
+	s.requireRefused(sent, pb.ErrorCode_ERROR_CODE_BAD_REQUEST)
+	s.Equal("you are not in a guild", sent[0].Message.GetError().Message)
+}
+
+func (s *ChatSuite) TestPlayersCannotAnnounce() {
+	sent := s.say(s.hero, &pb.Chat{Text: "hear ye", Scope: pb.ChatScope_CHAT_SCOPE_ANNOUNCEMENT})
+	s.requireRefused(sent, pb.ErrorCode_ERROR_CODE_UNAUTHORIZED)
+}
+
+func (s *ChatSuite) TestLength() {
+	s.requireRefused(s.say(s.hero, &pb.Chat{Text: "   "}), pb.ErrorCode_ERROR_CODE_BAD_REQUEST)
+	s.requireRefused(s.say(s.hero, &pb.Chat{Text: strings.Repeat("é", DefaultChatMaxLength+1)}), pb.ErrorCode_ERROR_CODE_BAD_REQUEST)
+
+	sent := s.say(s.hero, &pb.Chat{Text: strings.Repeat("é", DefaultChatMaxLength)})
+	s.NotNil(sent[0].Message.GetChat())
+}
+
+func (s *ChatSuite) TestRateLimit() {
+	s.start(Config{Chat: ChatConfig{Rate: 1, Burst: 2}})
+
+	s.NotNil(s.say(s.hero, &pb.Chat{Text: "one"})[0].Message.GetChat())
+	s.NotNil(s.say(s.hero, &pb.Chat{Text: "two"})[0].Message.GetChat())
+	s.requireRefused(s.say(s.hero, &pb.Chat{Text: "three"}), pb.ErrorCode_ERROR_CODE_RATE_LIMITED)
+
+	// Other players have their own allowance.
+	s.NotNil(s.say(s.sidekick, &pb.Chat{Text: "hi"})[0].Message.GetChat())
+
+	s.clock.now = s.clock.now.Add(time.Second)
+	s.NotNil(s.say(s.hero, &pb.Chat{Text: "four"})[0].Message.GetChat())
+}
+
+func (s *ChatSuite) TestFilter() {
+	s.start(Config{Chat: ChatConfig{Filter: shoutFilter{}}})
+
+	sent := s.say(s.hero, &pb.Chat{Text: "hello"})
+	s.Equal("HELLO", sent[0].Message.GetChat().Text)
+
+	sent = s.say(s.hero, &pb.Chat{Text: "buy spam"})
+	s.requireRefused(sent, pb.ErrorCode_ERROR_CODE_BAD_REQUEST)
+	s.Equal("no spam", sent[0].Message.GetError().Message)
+}
+
+func (s *ChatSuite) TestAnnounce() {
+	s.Require().NoError(s.game.Announce("server restarting"))
+	s.game.notices = append(s.game.notices, <-s.game.announcements)
+
+	sent := s.run()
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1, 2, 3}, sent[0].To)
+	chat := sent[0].Message.GetChat()
+	s.Equal("server restarting", chat.Text)
+	s.Equal(pb.ChatScope_CHAT_SCOPE_ANNOUNCEMENT, chat.Scope)
+}
+
+func (s *ChatSuite) TestAnnounceValidation() {
+	s.ErrorIs(s.game.Announce(" "), ErrInvalidChat)
+
+	for range announcementBuffer {
+		s.Require().NoError(s.game.Announce("hello"))
+	}
+	s.ErrorIs(s.game.Announce("hello"), ErrBusy)
+}
+
+func TestChat(t *testing.T) {
+	suite.Run(t, new(ChatSuite))
+}
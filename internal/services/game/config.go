@@ -0,0 +1,80 @@
+package game
+
+import "fmt"
+
+// DuplicateLoginPolicy decides what happens when a character that is already
+// in the world connects again.
+type DuplicateLoginPolicy int
+
+const (
+	// DuplicateLoginTakeOver disconnects the existing session and lets the new
+	// connection play. This lets players back in after a dropped connection.
+	DuplicateLoginTakeOver DuplicateLoginPolicy = iota
+	// DuplicateLoginReject refuses the new connection.
+	DuplicateLoginReject
+)
+
+// UnmarshalText parses "take_over" or "reject", so the policy can be set
+// from a config file.
+func (p *DuplicateLoginPolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "take_over":
+		*p = DuplicateLoginTakeOver
+	case "reject":
+		*p = DuplicateLoginReject
+	default:
+		return fmt.Errorf("unknown duplicate login policy %q, want take_over or reject", text)
+	}
+	return nil
+}
+
+// Config holds the tunable settings of the game simulation.
+type Config struct {
+	// TickRate is the simulation rate in ticks per second. Zero uses
+	// DefaultTickRate.
+	TickRate uint16 `yaml:"tick_rate"`
+	// Spawn is where characters are placed when they join.
+	Spawn Location `yaml:"spawn"`
+	// DuplicateLogin selects how repeat logins of a character are handled.
+	DuplicateLogin DuplicateLoginPolicy `yaml:"duplicate_login"`
+	// AdjacentMaps also shows players what happens on the maps linked from
+	// their own, so entities near a shared edge stay visible.
+	AdjacentMaps bool `yaml:"adjacent_maps"`
+	// Chat limits what players may say.
+	Chat ChatConfig `yaml:"chat"`
+}
+
+// ChatConfig limits player chat. Zero values use the defaults.
+type ChatConfig struct {
+	// MaxLength is the longest message, in characters, a player may send.
+	MaxLength int `yaml:"max_length"`
+	// Rate is the sustained number of messages per second a player may send.
+	Rate float64 `yaml:"rate"`
+	// Burst is how many messages a player may send at once before Rate applies.
+	Burst int `yaml:"burst"`
+	// Filter, when set, screens every player message before delivery.
+	Filter ChatFilter `yaml:"-"`
+	// Guilds, when set, routes guild chat to the sender's guild. Without it
+	// guild chat is refused.
+	Guilds GuildResolver `yaml:"-"`
+}
+
+// Chat defaults.
+const (
+	DefaultChatMaxLength = 200
+	DefaultChatRate      = 1
+	DefaultChatBurst     = 5
+)
+
+func (c ChatConfig) withDefaults() ChatConfig {
+	if c.MaxLength == 0 {
+		c.MaxLength = DefaultChatMaxLength
+	}
+	if c.Rate == 0 {
+		c.Rate = DefaultChatRate
+	}
+	if c.Burst == 0 {
+		c.Burst = DefaultChatBurst
+	}
+	return c
+}
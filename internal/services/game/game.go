@@ -8,44 +8,114 @@ package game
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
 )
 
+// DefaultTickRate is the simulation rate, in ticks per second, used when none
+// is configured.
+const DefaultTickRate = 20
+
 type Game struct {
 	wg   *sync.WaitGroup
 	once sync.Once
 
-	network chan any
+	events <-chan network.Event
+	out    chan<- network.Outbound
+
+	cfg      Config
+	clock    Clock
+	interval time.Duration
+	metrics  tickRecorder
+
+	world      *world
+	triggers   Triggers
+	characters Characters
+
+	// announcements carries Announce calls to the game loop.
+	announcements chan string
+	// changedMaps holds the maps reported to MapChanged since the last tick.
+	changedMu   sync.Mutex
+	changedMaps map[int]bool
+	// stop carries Stop's deadline to the game loop; done is closed when the
+	// loop exits.
+	stop chan context.Context
+	done chan struct{}
+
+	// Owned by the game loop goroutine.
+	inputs  []network.Event
+	notices []string
+	outbox  []network.Outbound
 }
 
-func New(network chan any) *Game {
+// New creates a Game that consumes client events, sends replies on out and
+// builds its world from the maps in source. Tile triggers are run by triggers,
+// or ignored when it is nil. Joining characters are checked against
+// characters, unless it is nil because they are kept by another server.
+func New(events <-chan network.Event, out chan<- network.Outbound, source MapSource, triggers Triggers, characters Characters, cfg Config) *Game {
+	if cfg.TickRate == 0 {
+		cfg.TickRate = DefaultTickRate
+	}
+	cfg.Chat = cfg.Chat.withDefaults()
 	return &Game{
-		network: network,
+		events:     events,
+		out:        out,
+		cfg:        cfg,
+		clock:      systemClock{},
+		interval:   time.Second / time.Duration(cfg.TickRate),
+		world:      newWorld(source, cfg.AdjacentMaps),
+		triggers:   triggers,
+		characters: characters,
+
+		announcements: make(chan string, announcementBuffer),
+		changedMaps:   make(map[int]bool),
+		stop:          make(chan context.Context),
 	}
 }
 
+// Start runs the game loop until Stop is called or ctx ends.
 func (g *Game) Start(ctx context.Context, wg *sync.WaitGroup) error {
-	var startErr error
 	g.once.Do(func() {
+		g.done = make(chan struct{})
 		g.wg = wg
 		g.wg.Add(1)
 		go func() {
-			startErr = g.start(ctx)
+			g.start(ctx)
+			close(g.done)
 			g.wg.Done()
 			slog.Info("game shutdown complete")
 		}()
 	})
-	return startErr
+	return nil
+}
+
+// Metrics returns a snapshot of the tick timing metrics.
+func (g *Game) Metrics() TickMetrics {
+	return g.metrics.snapshot()
 }
 
 func (g *Game) start(ctx context.Context) error {
+	ticker := g.clock.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	slog.Info("game loop starting", "interval", g.interval)
 	for {
 		select {
-		case msg := <-g.network:
-			slog.Info("game received message: ", "message", msg)
-			// msg.Type = new Client
-			//   game.NewPlayer(client)
+		case ev := <-g.events:
+			g.inputs = append(g.inputs, ev)
+		case text := <-g.announcements:
+			g.notices = append(g.notices, text)
+		case <-ticker.C():
+			g.tick(ctx)
+		case stopCtx := <-g.stop:
+			slog.Info("game shutting down")
+			g.shutdown(stopCtx)
+			return nil
 		case <-ctx.Done():
 			slog.Info("game shutting down")
 			return nil
@@ -53,7 +123,124 @@ func (g *Game) start(ctx context.Context) error {
 	}
 }
 
-// Stop shuts down the Game service
-func (g *Game) Stop() {
-	// Implement shutdown logic here
+// tick runs one step of the simulation: pick up changed maps, apply the
+// inputs received since the last tick, advance the world, then send
+// everything the step produced.
+func (g *Game) tick(ctx context.Context) {
+	started := g.clock.Now()
+
+	g.reloadChangedMaps(ctx)
+	inputs := g.inputs
+	g.inputs = nil
+	for _, ev := range inputs {
+		g.handleEvent(ctx, ev)
+	}
+	for _, text := range g.notices {
+		g.announce(ctx, text)
+	}
+	g.notices = nil
+	g.advance(g.interval)
+	g.flush(ctx)
+
+	elapsed := g.clock.Now().Sub(started)
+	if g.metrics.record(elapsed, g.interval) {
+		slog.WarnContext(ctx, "game tick overran", "elapsed", elapsed, "interval", g.interval, "inputs", len(inputs))
+	}
+}
+
+// advance moves time based world state forward by dt.
+func (g *Game) advance(dt time.Duration) {
+	// Nothing in the world is time driven yet.
+}
+
+// queue buffers a message to be sent when the current tick is flushed.
+func (g *Game) queue(out network.Outbound) {
+	g.outbox = append(g.outbox, out)
+}
+
+// flush sends every queued message, giving up if ctx ends first.
+func (g *Game) flush(ctx context.Context) {
+	outbox := g.outbox
+	g.outbox = nil
+	for _, out := range outbox {
+		select {
+		case g.out <- out:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *Game) handleEvent(ctx context.Context, ev network.Event) {
+	switch ev := ev.(type) {
+	case network.ClientConnected:
+		slog.InfoContext(ctx, "client connected", "client", ev.Client.ID(), "account", ev.Client.AccountID())
+		g.join(ctx, ev.Client)
+	case network.ClientDisconnected:
+		slog.InfoContext(ctx, "client disconnected", "client", ev.Client.ID(), "error", ev.Err)
+		g.leave(ctx, ev.Client)
+	case network.ClientMessage:
+		g.handleMessage(ctx, ev.Client, ev.Message)
+	}
+}
+
+func (g *Game) handleMessage(ctx context.Context, client *network.Client, msg *pb.GameMessage) {
+	slog.DebugContext(ctx, "client message", "client", client.ID(), "payload", fmt.Sprintf("%T", msg.Payload))
+
+	p, ok := g.world.byClient[client.ID()]
+	if !ok {
+		// The client was rejected or replaced; ignore whatever it still sends.
+		return
+	}
+
+	switch payload := msg.Payload.(type) {
+	case *pb.GameMessage_Move:
+		g.move(ctx, p, payload.Move)
+	case *pb.GameMessage_Interact:
+		g.interact(ctx, p)
+	case *pb.GameMessage_Chat:
+		g.chat(ctx, p, payload.Chat)
+	case *pb.GameMessage_JoinGame:
+		g.queue(network.ToClient(client.ID(), errorMessage(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, "already in game")))
+	default:
+		g.queue(network.ToClient(client.ID(), errorMessage(pb.ErrorCode_ERROR_CODE_BAD_REQUEST, "unsupported message")))
+	}
+}
+
+// Stop asks the game loop to finish: pending inputs are applied, every
+// player is told the server is going down and removed from the world, and
+// the final messages are flushed. It returns once the loop has exited or ctx
+// ends.
+func (g *Game) Stop(ctx context.Context) error {
+	if g.done == nil {
+		return nil
+	}
+	select {
+	case g.stop <- ctx:
+	case <-g.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-g.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// shutdown runs a last tick and removes every player, flushing until ctx
+// ends.
+func (g *Game) shutdown(ctx context.Context) {
+	inputs := g.inputs
+	g.inputs = nil
+	for _, ev := range inputs {
+		g.handleEvent(ctx, ev)
+	}
+	for _, p := range g.world.everyone() {
+		g.queue(network.ToClient(p.client.ID(), disconnectMessage(pb.DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN, "server shutting down")))
+		g.world.removePlayer(p)
+	}
+	g.flush(ctx)
 }
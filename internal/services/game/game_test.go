@@ -0,0 +1,201 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// fakeClock is a Clock whose time only moves when told to. Each call to Now
+// advances time by step, simulating work done between readings.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	step   time.Duration
+	ticker *fakeTicker
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func (c *fakeClock) NewTicker(time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ticker = &fakeTicker{c: make(chan time.Time)}
+	return c.ticker
+}
+
+// tick delivers a single tick, blocking until the loop receives it.
+func (c *fakeClock) tick() {
+	c.mu.Lock()
+	t := c.ticker
+	c.mu.Unlock()
+	t.c <- c.Now()
+}
+
+type fakeTicker struct {
+	c chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+func (t *fakeTicker) Stop()               {}
+
+// memoryMaps is a MapSource holding maps in memory.
+type memoryMaps map[int]*maps.Map
+
+func (m memoryMaps) Get(id int) (*maps.Map, error) {
+	if mp, ok := m[id]; ok {
+		return mp, nil
+	}
+	return nil, fmt.Errorf("map %d not found", id)
+}
+
+// memoryCharacters is a Characters holding the inventories of existing
+// characters by ID.
+type memoryCharacters map[string]map[string]int
+
+func (c memoryCharacters) Exists(id string) (bool, error) {
+	_, ok := c[id]
+	return ok, nil
+}
+
+func (c memoryCharacters) GiveItem(id, itemID string, count int) error {
+	inventory, ok := c[id]
+	if !ok {
+		return fmt.Errorf("character %s not found", id)
+	}
+	inventory[itemID] += count
+	return nil
+}
+
+// newClient returns a network client with no connection, as the game sees it.
+func newClient(id uint64, characterID string) *network.Client {
+	return network.NewClient(id, nil, &auth.Claims{CharacterID: characterID})
+}
+
+type GameSuite struct {
+	suite.Suite
+	events chan network.Event
+	out    chan network.Outbound
+	clock  *fakeClock
+	game   *Game
+}
+
+func (s *GameSuite) SetupTest() {
+	s.events = make(chan network.Event, 10)
+	s.out = make(chan network.Outbound, 10)
+	s.clock = &fakeClock{now: time.Unix(0, 0)}
+	s.game = New(s.events, s.out, memoryMaps{1: maps.NewMap(1, "Spawn")}, nil, nil, Config{TickRate: 20, Spawn: Location{MapID: 1}})
+	s.game.clock = s.clock
+}
+
+func (s *GameSuite) TestTickRate() {
+	s.Equal(50*time.Millisecond, s.game.interval)
+	s.Equal(time.Second/DefaultTickRate, New(nil, nil, nil, nil, nil, Config{}).interval)
+}
+
+func (s *GameSuite) TestInputsWaitForTick() {
+	s.game.inputs = append(s.game.inputs, network.ClientConnected{Client: newClient(1, "hero")})
+	s.Empty(s.out, "nothing is sent before the tick")
+
+	s.game.tick(context.Background())
+
+	s.Empty(s.game.inputs)
+	s.Require().Len(s.out, 1)
+	out := <-s.out
+	s.NotNil(out.Message.GetMapChange())
+}
+
+func (s *GameSuite) TestMetrics() {
+	s.clock.step = 10 * time.Millisecond
+	s.game.tick(context.Background())
+	s.game.tick(context.Background())
+
+	m := s.game.Metrics()
+	s.Equal(uint64(2), m.Ticks)
+	s.Zero(m.Overruns)
+	s.Equal(10*time.Millisecond, m.Last)
+	s.Equal(10*time.Millisecond, m.Mean())
+}
+
+func (s *GameSuite) TestOverrun() {
+	s.clock.step = 80 * time.Millisecond
+	s.game.tick(context.Background())
+
+	m := s.game.Metrics()
+	s.Equal(uint64(1), m.Overruns)
+	s.Equal(80*time.Millisecond, m.Max)
+}
+
+func (s *GameSuite) TestLoopTicksUntilCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.game.start(ctx) }()
+
+	s.Eventually(func() bool {
+		s.clock.mu.Lock()
+		defer s.clock.mu.Unlock()
+		return s.clock.ticker != nil
+	}, time.Second, time.Millisecond)
+
+	s.events <- network.ClientConnected{Client: newClient(1, "hero")}
+	s.Eventually(func() bool { return len(s.events) == 0 }, time.Second, time.Millisecond)
+	s.clock.tick()
+	s.clock.tick()
+
+	select {
+	case <-s.out:
+	case <-time.After(time.Second):
+		s.Fail("queued input was not processed on tick")
+	}
+	s.Eventually(func() bool { return s.game.Metrics().Ticks == 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		s.NoError(err)
+	case <-time.After(time.Second):
+		s.Fail("game loop did not stop")
+	}
+}
+
+func (s *GameSuite) TestStopDisconnectsPlayers() {
+	wg := &sync.WaitGroup{}
+	s.Require().NoError(s.game.Start(context.Background(), wg))
+
+	// Stop applies inputs that arrived since the last tick.
+	s.events <- network.ClientConnected{Client: newClient(1, "hero")}
+	s.Eventually(func() bool { return len(s.events) == 0 }, time.Second, time.Millisecond)
+
+	s.Require().NoError(s.game.Stop(context.Background()))
+	wg.Wait()
+
+	s.Require().Len(s.out, 2)
+	s.NotNil((<-s.out).Message.GetMapChange())
+	bye := (<-s.out).Message.GetDisconnect()
+	s.Require().NotNil(bye)
+	s.Equal(pb.DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN, bye.Reason)
+	s.Empty(s.game.world.players)
+
+	// Stopping again, or a game that never started, is harmless.
+	s.NoError(s.game.Stop(context.Background()))
+	s.NoError(New(nil, nil, nil, nil, nil, Config{}).Stop(context.Background()))
+}
+
+func TestGame(t *testing.T) {
+	suite.Run(t, new(GameSuite))
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// MapChanged tells the game that map id was saved or deleted outside it. It is
+// safe to call from any goroutine and never blocks; the map is reloaded on the
+// next tick.
+func (g *Game) MapChanged(id int) {
+	g.changedMu.Lock()
+	defer g.changedMu.Unlock()
+	g.changedMaps[id] = true
+}
+
+// reloadChangedMaps reloads every map reported to MapChanged since the last
+// tick.
+func (g *Game) reloadChangedMaps(ctx context.Context) {
+	g.changedMu.Lock()
+	changed := g.changedMaps
+	g.changedMaps = make(map[int]bool)
+	g.changedMu.Unlock()
+
+	ids := make([]int, 0, len(changed))
+	for id := range changed {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		g.reloadMap(ctx, id)
+	}
+}
+
+// reloadMap replaces the world's copy of map id with the stored one. Players
+// that can see the map are sent the tiles whose attributes changed, and
+// players on it are told the new version so they refetch the rest. Attributes
+// set by scripts are lost, as they would be on a restart.
+//
+// A map that can no longer be loaded, usually because it was deleted, is
+// forgotten unless players are on or next to it; they keep the old copy until
+// they leave.
+func (g *Game) reloadMap(ctx context.Context, id int) {
+	old, ok := g.world.maps[id]
+	if !ok {
+		// Not loaded yet, so its first use reads the stored copy.
+		return
+	}
+	m, err := g.world.source.Get(id)
+	if err != nil {
+		if r, ok := g.world.rooms[id]; !ok || r.empty() {
+			delete(g.world.maps, id)
+		}
+		slog.WarnContext(ctx, "changed map could not be reloaded", "map", id, "error", err)
+		return
+	}
+	g.world.maps[id] = m
+	slog.InfoContext(ctx, "map reloaded", "map", id, "version", m.Version)
+
+	for x := range m.Tiles {
+		for y := range m.Tiles[x] {
+			if sameAttributes(old.Tiles[x][y].Attributes, m.Tiles[x][y].Attributes) {
+				continue
+			}
+			attrs := make(map[string]string, len(m.Tiles[x][y].Attributes))
+			for k, v := range m.Tiles[x][y].Attributes {
+				attrs[k] = v
+			}
+			g.broadcastMap(id, nil, message(&pb.TileChange{
+				Position:   position(Location{MapID: id, X: x, Y: y}),
+				Attributes: attrs,
+			}))
+		}
+	}
+
+	r, ok := g.world.rooms[id]
+	if !ok {
+		return
+	}
+	for _, p := range sorted(r.members) {
+		// Links may have changed, and with them the maps p can see.
+		g.world.leaveRooms(p)
+		g.world.enterRooms(p)
+		g.queue(network.ToClient(p.client.ID(), g.mapChange(p, m.Version)))
+	}
+}
+
+// sameAttributes reports whether a and b hold the same attributes, treating
+// nil as empty.
+func sameAttributes(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/admin/audit"
+	adminmaps "github.com/Odyssey-Classic/server/internal/services/admin/maps"
+	filestore "github.com/Odyssey-Classic/server/internal/services/admin/maps/store/file"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+)
+
+// MapChangesSuite edits maps through the admin maps API while players are on
+// them, with the game and the API sharing one store as they do in the server.
+type MapChangesSuite struct {
+	suite.Suite
+	store  *filestore.FileStore
+	admin  chi.Router
+	out    chan network.Outbound
+	game   *Game
+	hero   *Player
+	fellow *Player
+}
+
+func (s *MapChangesSuite) SetupTest() {
+	var err error
+	s.store, err = filestore.New(s.T().TempDir())
+	s.Require().NoError(err)
+	// Map 1 and map 2 are linked east to west.
+	spawn := openMap(0)
+	spawn.Links.East = 2
+	field := openMap(0)
+	field.Links.West = 1
+	for _, m := range []*maps.Map{spawn, field} {
+		_, err := s.store.CreateFrom(m)
+		s.Require().NoError(err)
+	}
+
+	s.out = make(chan network.Outbound, 100)
+	s.game = New(nil, s.out, s.store, nil, nil, Config{AdjacentMaps: true, Spawn: Location{MapID: 1, X: 8, Y: 8}})
+
+	log, err := audit.NewFileLog(s.T().TempDir())
+	s.Require().NoError(err)
+	s.admin = chi.NewRouter()
+	s.admin.Mount("/admin/maps", adminmaps.NewWithStore(s.store, log, s.game).Routes())
+
+	// hero is on map 1; fellow is on map 2 and watches map 1 across the link.
+	s.run(network.ClientConnected{Client: newClient(1, "hero")}, network.ClientConnected{Client: newClient(2, "fellow")})
+	s.hero = s.game.world.byCharacter["hero"]
+	s.fellow = s.game.world.byCharacter["fellow"]
+	s.game.world.place(s.fellow, Location{MapID: 2, X: 8, Y: 8})
+}
+
+func (s *MapChangesSuite) run(events ...network.Event) []network.Outbound {
+	s.game.inputs = append(s.game.inputs, events...)
+	s.game.tick(context.Background())
+	var sent []network.Outbound
+	for len(s.out) > 0 {
+		sent = append(sent, <-s.out)
+	}
+	return sent
+}
+
+func (s *MapChangesSuite) edit(method, path string, body any) {
+	data, err := json.Marshal(body)
+	s.Require().NoError(err)
+	w := httptest.NewRecorder()
+	s.admin.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewReader(data)))
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+}
+
+func (s *MapChangesSuite) TestTileEditReachesRoom() {
+	s.edit(http.MethodPut, "/admin/maps/1/tiles/3/4", maps.Tile{Passable: false, Attributes: map[string]string{"sign": "Welcome"}})
+
+	sent := s.run()
+
+	m, err := s.game.world.mapByID(1)
+	s.Require().NoError(err)
+	s.False(m.Tiles[3][4].Passable)
+	s.Require().Len(sent, 2)
+
+	change := sent[0].Message.GetTileChange()
+	s.Require().NotNil(change)
+	s.Equal([]uint64{1, 2}, sent[0].To)
+	s.Equal(int32(3), change.Position.X)
+	s.Equal(int32(4), change.Position.Y)
+	s.Equal(map[string]string{"sign": "Welcome"}, change.Attributes)
+
+	// Only the player on the map refetches it.
+	s.Equal([]uint64{1}, sent[1].To)
+	s.Equal(int32(m.Version), sent[1].Message.GetMapChange().GetMapVersion())
+	s.Equal(int32(1), sent[1].Message.GetMapChange().GetMapId())
+}
+
+func (s *MapChangesSuite) TestUnlinkedMapLeavesView() {
+	m, err := s.store.Get(1)
+	s.Require().NoError(err)
+	m.Links.East = 0
+	s.edit(http.MethodPut, "/admin/maps/1", m)
+
+	sent := s.run()
+
+	s.Equal([]int{1}, s.hero.watching)
+	s.Require().Len(sent, 1)
+	s.Empty(sent[0].Message.GetMapChange().GetEntities())
+}
+
+func (s *MapChangesSuite) TestDeletedMapKeptWhileOccupied() {
+	w := httptest.NewRecorder()
+	s.admin.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/maps/1", nil))
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	s.Empty(s.run())
+
+	_, err := s.game.world.mapByID(1)
+	s.NoError(err, "players on the map keep the copy they have")
+}
+
+func TestMapChanges(t *testing.T) {
+	suite.Run(t, new(MapChangesSuite))
+}
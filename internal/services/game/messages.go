@@ -0,0 +1,83 @@
+package game
+
+import (
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// message wraps a payload in a GameMessage envelope.
+func message(payload any) *pb.GameMessage {
+	msg := &pb.GameMessage{Version: pb.ProtocolVersion_PROTOCOL_VERSION_1}
+	switch p := payload.(type) {
+	case *pb.JoinGame:
+		msg.Payload = &pb.GameMessage_JoinGame{JoinGame: p}
+	case *pb.Move:
+		msg.Payload = &pb.GameMessage_Move{Move: p}
+	case *pb.Chat:
+		msg.Payload = &pb.GameMessage_Chat{Chat: p}
+	case *pb.MapChange:
+		msg.Payload = &pb.GameMessage_MapChange{MapChange: p}
+	case *pb.EntitySpawn:
+		msg.Payload = &pb.GameMessage_EntitySpawn{EntitySpawn: p}
+	case *pb.EntityDespawn:
+		msg.Payload = &pb.GameMessage_EntityDespawn{EntityDespawn: p}
+	case *pb.Error:
+		msg.Payload = &pb.GameMessage_Error{Error: p}
+	case *pb.Disconnect:
+		msg.Payload = &pb.GameMessage_Disconnect{Disconnect: p}
+	case *pb.Interact:
+		msg.Payload = &pb.GameMessage_Interact{Interact: p}
+	case *pb.TileChange:
+		msg.Payload = &pb.GameMessage_TileChange{TileChange: p}
+	default:
+		panic("game: unknown message payload")
+	}
+	return msg
+}
+
+// errorMessage builds an Error reply.
+func errorMessage(code pb.ErrorCode, text string) *pb.GameMessage {
+	return message(&pb.Error{Code: code, Message: text})
+}
+
+// disconnectMessage builds the final message sent before the network closes a
+// connection.
+func disconnectMessage(reason pb.DisconnectReason, text string) *pb.GameMessage {
+	return message(&pb.Disconnect{Reason: reason, Message: text})
+}
+
+func position(l Location) *pb.Position {
+	return &pb.Position{MapId: int32(l.MapID), X: int32(l.X), Y: int32(l.Y)}
+}
+
+// direction converts a map direction to its protocol value.
+func direction(d maps.Direction) pb.Direction {
+	switch d {
+	case maps.North:
+		return pb.Direction_DIRECTION_NORTH
+	case maps.East:
+		return pb.Direction_DIRECTION_EAST
+	case maps.South:
+		return pb.Direction_DIRECTION_SOUTH
+	case maps.West:
+		return pb.Direction_DIRECTION_WEST
+	default:
+		return pb.Direction_DIRECTION_UNSPECIFIED
+	}
+}
+
+// mapDirection converts a protocol direction to a map direction.
+func mapDirection(d pb.Direction) (maps.Direction, bool) {
+	switch d {
+	case pb.Direction_DIRECTION_NORTH:
+		return maps.North, true
+	case pb.Direction_DIRECTION_EAST:
+		return maps.East, true
+	case pb.Direction_DIRECTION_SOUTH:
+		return maps.South, true
+	case pb.Direction_DIRECTION_WEST:
+		return maps.West, true
+	default:
+		return 0, false
+	}
+}
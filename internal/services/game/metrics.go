@@ -0,0 +1,55 @@
+package game
+
+import (
+	"sync"
+	"time"
+)
+
+// TickMetrics summarises how long ticks have taken to run.
+type TickMetrics struct {
+	// Ticks is the number of ticks run so far.
+	Ticks uint64
+	// Overruns counts ticks that took longer than the tick interval.
+	Overruns uint64
+	Last     time.Duration
+	Max      time.Duration
+	Total    time.Duration
+}
+
+// Mean returns the average tick duration.
+func (m TickMetrics) Mean() time.Duration {
+	if m.Ticks == 0 {
+		return 0
+	}
+	return m.Total / time.Duration(m.Ticks)
+}
+
+// tickRecorder accumulates TickMetrics. It is safe for concurrent use.
+type tickRecorder struct {
+	mu      sync.Mutex
+	metrics TickMetrics
+}
+
+// record adds a tick of duration d and reports whether it overran interval.
+func (r *tickRecorder) record(d, interval time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metrics.Ticks++
+	r.metrics.Last = d
+	r.metrics.Total += d
+	if d > r.metrics.Max {
+		r.metrics.Max = d
+	}
+	overran := d > interval
+	if overran {
+		r.metrics.Overruns++
+	}
+	return overran
+}
+
+func (r *tickRecorder) snapshot() TickMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.metrics
+}
@@ -0,0 +1,191 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/game/scripts"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// Reasons a move can be refused. They are reported to the client verbatim.
+var (
+	errInvalidDirection = errors.New("invalid direction")
+	errBlocked          = errors.New("blocked")
+	errOccupied         = errors.New("occupied")
+	errNoExit           = errors.New("no map in that direction")
+	errMapUnavailable   = errors.New("map unavailable")
+)
+
+// move handles a player's request to step one tile. The server decides where
+// the player ends up; a refused move resyncs the client to its real position.
+// The player turns to face dir even when the step is refused, so it can
+// interact with whatever blocked it.
+func (g *Game) move(ctx context.Context, p *Player, req *pb.Move) {
+	dir, ok := mapDirection(req.GetDirection())
+	if !ok {
+		g.rejectMove(ctx, p, req.GetSequence(), errInvalidDirection)
+		return
+	}
+	p.facing = dir
+
+	dest, m, err := g.checkMove(p.location, dir)
+	if err != nil {
+		g.rejectMove(ctx, p, req.GetSequence(), err)
+		return
+	}
+
+	from := p.location
+	g.fireTrigger(ctx, p, from, scripts.Leave)
+	if p.location != from {
+		// The leave trigger moved the player elsewhere.
+		return
+	}
+
+	if dest.MapID == p.location.MapID {
+		g.world.place(p, dest)
+		g.broadcastMap(dest.MapID, nil, moveMessage(p, req.GetSequence()))
+	} else {
+		g.transfer(ctx, p, dest, m)
+	}
+
+	g.fireTrigger(ctx, p, dest, scripts.Enter)
+	if p.location != dest {
+		// The enter trigger moved the player elsewhere.
+		return
+	}
+
+	if tile, err := m.GetTile(dest.X, dest.Y); err == nil && tile.Warp != nil {
+		if err := g.warp(ctx, p, *tile.Warp); err != nil {
+			slog.WarnContext(ctx, "warp refused", "entity", p.entityID, "map", dest.MapID, "x", dest.X, "y", dest.Y, "error", err)
+		}
+	}
+}
+
+// checkMove validates a one tile step from loc in direction dir and returns
+// where it lands along with the map it lands on. Steps off the edge of a map
+// continue onto the map linked on that side.
+func (g *Game) checkMove(loc Location, dir maps.Direction) (Location, *maps.Map, error) {
+	src, err := g.world.mapByID(loc.MapID)
+	if err != nil {
+		return Location{}, nil, errMapUnavailable
+	}
+	from, err := src.GetTile(loc.X, loc.Y)
+	if err != nil {
+		return Location{}, nil, err
+	}
+	if !from.CanExit(dir) {
+		return Location{}, nil, errBlocked
+	}
+
+	dx, dy := dir.Delta()
+	dest := Location{MapID: loc.MapID, X: loc.X + dx, Y: loc.Y + dy}
+	m := src
+	if !maps.InBounds(dest.X, dest.Y) {
+		dest.MapID = src.Links.Get(dir)
+		if dest.MapID == 0 {
+			return Location{}, nil, errNoExit
+		}
+		if m, err = g.world.mapByID(dest.MapID); err != nil {
+			return Location{}, nil, errMapUnavailable
+		}
+		dest.X = (dest.X + maps.Size) % maps.Size
+		dest.Y = (dest.Y + maps.Size) % maps.Size
+	}
+
+	to, err := m.GetTile(dest.X, dest.Y)
+	if err != nil {
+		return Location{}, nil, err
+	}
+	if !to.CanEnter(dir.Opposite()) {
+		return Location{}, nil, errBlocked
+	}
+	if g.world.occupant(dest) != nil {
+		return Location{}, nil, errOccupied
+	}
+	return dest, m, nil
+}
+
+// rejectMove tells the player why the move failed and where it really is.
+func (g *Game) rejectMove(ctx context.Context, p *Player, sequence uint32, reason error) {
+	slog.DebugContext(ctx, "move rejected", "entity", p.entityID, "reason", reason)
+	g.queue(network.ToClient(p.client.ID(), errorMessage(pb.ErrorCode_ERROR_CODE_MOVE_REJECTED, reason.Error())))
+	g.queue(network.ToClient(p.client.ID(), moveMessage(p, sequence)))
+}
+
+// transfer moves p to dest on map m, which differs from its current map.
+// Players that could see p before and after only see it move; the rest see it
+// despawn or spawn.
+func (g *Game) transfer(ctx context.Context, p *Player, dest Location, m *maps.Map) {
+	slog.DebugContext(ctx, "player changing map", "entity", p.entityID, "from", p.location.MapID, "to", dest.MapID)
+	before := g.world.audience(p.location.MapID)
+	g.world.place(p, dest)
+	after := g.world.audience(dest.MapID)
+
+	left, stayed, joined := split(before, after)
+	g.send(left, p, message(&pb.EntityDespawn{EntityId: p.entityID}))
+	g.queue(network.ToClient(p.client.ID(), g.mapChange(p, m.Version)))
+	g.send(stayed, p, moveMessage(p, 0))
+	g.send(joined, p, message(&pb.EntitySpawn{Entity: p.entity()}))
+}
+
+// split partitions two audiences into the players only in before, in both,
+// and only in after.
+func split(before, after []*Player) (left, stayed, joined []*Player) {
+	inAfter := make(map[*Player]bool, len(after))
+	for _, p := range after {
+		inAfter[p] = true
+	}
+	inBefore := make(map[*Player]bool, len(before))
+	for _, p := range before {
+		inBefore[p] = true
+		if inAfter[p] {
+			stayed = append(stayed, p)
+		} else {
+			left = append(left, p)
+		}
+	}
+	for _, p := range after {
+		if !inBefore[p] {
+			joined = append(joined, p)
+		}
+	}
+	return left, stayed, joined
+}
+
+// moveMessage announces p's authoritative position.
+func moveMessage(p *Player, sequence uint32) *pb.GameMessage {
+	return message(&pb.Move{
+		EntityId:  p.entityID,
+		Direction: direction(p.facing),
+		Position:  position(p.location),
+		Sequence:  sequence,
+	})
+}
+
+// warp sends p to the warp's destination. Warps ignore occupancy, do not
+// chain and do not fire triggers: only steps do. A warp whose destination is
+// unusable is refused so the player is left where it is.
+func (g *Game) warp(ctx context.Context, p *Player, to maps.WarpDestination) error {
+	if !maps.InBounds(to.X, to.Y) {
+		return fmt.Errorf("warp destination (%d, %d) out of range", to.X, to.Y)
+	}
+	m, err := g.world.mapByID(to.MapID)
+	if err != nil {
+		return err
+	}
+
+	dest := Location{MapID: to.MapID, X: to.X, Y: to.Y}
+	slog.DebugContext(ctx, "player warping", "entity", p.entityID, "from", p.location, "to", dest)
+	if dest.MapID == p.location.MapID {
+		g.world.place(p, dest)
+		g.broadcastMap(dest.MapID, nil, moveMessage(p, 0))
+		return nil
+	}
+	g.transfer(ctx, p, dest, m)
+	return nil
+}
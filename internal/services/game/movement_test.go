@@ -0,0 +1,225 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// openMap returns a map on which every tile is passable.
+func openMap(id int) *maps.Map {
+	m := maps.NewMap(id, "Open")
+	for x := range m.Tiles {
+		for y := range m.Tiles[x] {
+			m.Tiles[x][y].Passable = true
+		}
+	}
+	return m
+}
+
+type MovementSuite struct {
+	suite.Suite
+	maps memoryMaps
+	out  chan network.Outbound
+	game *Game
+	hero *Player
+}
+
+func (s *MovementSuite) SetupTest() {
+	s.maps = memoryMaps{1: openMap(1), 2: openMap(2)}
+	s.maps[1].Links.East = 2
+	s.out = make(chan network.Outbound, 100)
+	s.game = New(nil, s.out, s.maps, nil, nil, Config{Spawn: Location{MapID: 1, X: 8, Y: 8}})
+
+	s.run(network.ClientConnected{Client: newClient(1, "hero")})
+	s.hero = s.game.world.byCharacter["hero"]
+}
+
+func (s *MovementSuite) run(events ...network.Event) []network.Outbound {
+	s.game.inputs = append(s.game.inputs, events...)
+	s.game.tick(context.Background())
+	var sent []network.Outbound
+	for len(s.out) > 0 {
+		sent = append(sent, <-s.out)
+	}
+	return sent
+}
+
+func (s *MovementSuite) moveHero(d pb.Direction, sequence uint32) []network.Outbound {
+	return s.run(network.ClientMessage{
+		Client:  s.hero.client,
+		Message: message(&pb.Move{Direction: d, Sequence: sequence}),
+	})
+}
+
+// requireRejected checks the reply to a refused move resyncs the client.
+func (s *MovementSuite) requireRejected(sent []network.Outbound, reason error, at Location) {
+	s.Require().Len(sent, 2)
+	s.Equal(pb.ErrorCode_ERROR_CODE_MOVE_REJECTED, sent[0].Message.GetError().GetCode())
+	s.Equal(reason.Error(), sent[0].Message.GetError().GetMessage())
+	resync := sent[1].Message.GetMove()
+	s.Require().NotNil(resync)
+	s.Equal(s.hero.entityID, resync.EntityId)
+	s.Equal(position(at), resync.Position)
+	s.Equal(at, s.hero.location)
+}
+
+func (s *MovementSuite) TestMove() {
+	s.run(network.ClientConnected{Client: newClient(2, "sidekick")})
+	sidekick := s.game.world.byCharacter["sidekick"]
+	sidekick.location.X = 0
+
+	sent := s.moveHero(pb.Direction_DIRECTION_NORTH, 7)
+
+	s.Require().Len(sent, 1)
+	s.ElementsMatch([]uint64{1, 2}, sent[0].To)
+	move := sent[0].Message.GetMove()
+	s.Equal(uint32(7), move.Sequence)
+	s.Equal(int32(7), move.Position.Y)
+	s.Equal(Location{MapID: 1, X: 8, Y: 7}, s.hero.location)
+	s.Equal(maps.North, s.hero.facing)
+}
+
+func (s *MovementSuite) TestInvalidDirection() {
+	sent := s.moveHero(pb.Direction_DIRECTION_UNSPECIFIED, 1)
+	s.requireRejected(sent, errInvalidDirection, Location{MapID: 1, X: 8, Y: 8})
+}
+
+func (s *MovementSuite) TestImpassable() {
+	s.maps[1].Tiles[9][8].Passable = false
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+	s.requireRejected(sent, errBlocked, Location{MapID: 1, X: 8, Y: 8})
+}
+
+func (s *MovementSuite) TestOutboundBlock() {
+	s.maps[1].Tiles[8][8].BlockedDirections = []maps.DirectionalBlock{{Direction: maps.East, BlockOutbound: true}}
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+	s.requireRejected(sent, errBlocked, Location{MapID: 1, X: 8, Y: 8})
+
+	// Other sides are unaffected.
+	sent = s.moveHero(pb.Direction_DIRECTION_WEST, 2)
+	s.Require().Len(sent, 1)
+	s.NotNil(sent[0].Message.GetMove())
+}
+
+func (s *MovementSuite) TestInboundBlock() {
+	// Entering 9,8 from the west means coming through its west side.
+	s.maps[1].Tiles[9][8].BlockedDirections = []maps.DirectionalBlock{{Direction: maps.West, BlockInbound: true}}
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+	s.requireRejected(sent, errBlocked, Location{MapID: 1, X: 8, Y: 8})
+}
+
+func (s *MovementSuite) TestOccupied() {
+	s.run(network.ClientConnected{Client: newClient(2, "sidekick")})
+	s.game.world.place(s.game.world.byCharacter["sidekick"], Location{MapID: 1, X: 8, Y: 9})
+
+	sent := s.moveHero(pb.Direction_DIRECTION_SOUTH, 1)
+	s.requireRejected(sent, errOccupied, Location{MapID: 1, X: 8, Y: 8})
+}
+
+func (s *MovementSuite) TestEdgeWithoutLink() {
+	s.game.world.place(s.hero, Location{MapID: 1, X: 0, Y: 8})
+	sent := s.moveHero(pb.Direction_DIRECTION_WEST, 1)
+	s.requireRejected(sent, errNoExit, Location{MapID: 1, X: 0, Y: 8})
+}
+
+func (s *MovementSuite) TestEdgeToMissingMap() {
+	s.maps[1].Links.West = 99
+	s.game.world.place(s.hero, Location{MapID: 1, X: 0, Y: 8})
+	sent := s.moveHero(pb.Direction_DIRECTION_WEST, 1)
+	s.requireRejected(sent, errMapUnavailable, Location{MapID: 1, X: 0, Y: 8})
+}
+
+func (s *MovementSuite) TestEdgeFollowsLink() {
+	// A watcher on each map.
+	s.run(network.ClientConnected{Client: newClient(2, "left")})
+	s.game.world.place(s.game.world.byCharacter["left"], Location{MapID: 1, X: 3, Y: 3})
+	s.run(network.ClientConnected{Client: newClient(3, "right")})
+	s.game.world.place(s.game.world.byCharacter["right"], Location{MapID: 2, X: 3, Y: 3})
+
+	s.game.world.place(s.hero, Location{MapID: 1, X: maps.Size - 1, Y: 8})
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+
+	s.Equal(Location{MapID: 2, X: 0, Y: 8}, s.hero.location)
+	s.Require().Len(sent, 3)
+	s.Equal([]uint64{2}, sent[0].To)
+	s.Equal(s.hero.entityID, sent[0].Message.GetEntityDespawn().GetEntityId())
+	s.Equal([]uint64{1}, sent[1].To)
+	change := sent[1].Message.GetMapChange()
+	s.Require().NotNil(change)
+	s.Equal(int32(2), change.MapId)
+	s.Len(change.Entities, 1)
+	s.Equal([]uint64{3}, sent[2].To)
+	s.Equal(s.hero.entityID, sent[2].Message.GetEntitySpawn().GetEntity().GetId())
+}
+
+func (s *MovementSuite) TestWarpToOtherMap() {
+	s.run(network.ClientConnected{Client: newClient(2, "left")})
+	s.game.world.place(s.game.world.byCharacter["left"], Location{MapID: 1, X: 3, Y: 3})
+	s.run(network.ClientConnected{Client: newClient(3, "right")})
+	s.game.world.place(s.game.world.byCharacter["right"], Location{MapID: 2, X: 3, Y: 3})
+	s.maps[1].Tiles[9][8].Warp = &maps.WarpDestination{MapID: 2, X: 4, Y: 5}
+
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+
+	s.Equal(Location{MapID: 2, X: 4, Y: 5}, s.hero.location)
+	s.Require().Len(sent, 4)
+	// The step onto the warp tile is seen by everyone on the old map...
+	s.ElementsMatch([]uint64{1, 2}, sent[0].To)
+	s.NotNil(sent[0].Message.GetMove())
+	// ...then the hero vanishes from it...
+	s.Equal([]uint64{2}, sent[1].To)
+	s.NotNil(sent[1].Message.GetEntityDespawn())
+	// ...is sent the new map...
+	s.Equal([]uint64{1}, sent[2].To)
+	s.Equal(int32(2), sent[2].Message.GetMapChange().GetMapId())
+	// ...and appears there.
+	s.Equal([]uint64{3}, sent[3].To)
+	s.NotNil(sent[3].Message.GetEntitySpawn())
+}
+
+func (s *MovementSuite) TestWarpWithinMap() {
+	s.maps[1].Tiles[9][8].Warp = &maps.WarpDestination{MapID: 1, X: 1, Y: 1}
+
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+
+	s.Equal(Location{MapID: 1, X: 1, Y: 1}, s.hero.location)
+	s.Require().Len(sent, 2)
+	s.Equal(int32(1), sent[1].Message.GetMove().GetPosition().GetX())
+}
+
+func (s *MovementSuite) TestWarpToMissingMapRefused() {
+	s.maps[1].Tiles[9][8].Warp = &maps.WarpDestination{MapID: 99, X: 1, Y: 1}
+
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+
+	s.Equal(Location{MapID: 1, X: 9, Y: 8}, s.hero.location)
+	s.Len(sent, 1)
+}
+
+func (s *MovementSuite) TestWarpOutOfRangeRefused() {
+	s.maps[1].Tiles[9][8].Warp = &maps.WarpDestination{MapID: 2, X: maps.Size, Y: 1}
+
+	sent := s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+
+	s.Equal(Location{MapID: 1, X: 9, Y: 8}, s.hero.location)
+	s.Len(sent, 1)
+}
+
+func (s *MovementSuite) TestWarpsDoNotChain() {
+	s.maps[1].Tiles[9][8].Warp = &maps.WarpDestination{MapID: 2, X: 4, Y: 5}
+	s.maps[2].Tiles[4][5].Warp = &maps.WarpDestination{MapID: 1, X: 0, Y: 0}
+
+	s.moveHero(pb.Direction_DIRECTION_EAST, 1)
+
+	s.Equal(Location{MapID: 2, X: 4, Y: 5}, s.hero.location)
+}
+
+func TestMovement(t *testing.T) {
+	suite.Run(t, new(MovementSuite))
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// Location is a tile on a specific map.
+type Location struct {
+	MapID int `yaml:"map"`
+	X     int `yaml:"x"`
+	Y     int `yaml:"y"`
+}
+
+// Player is a character in the world, bound to the client controlling it.
+type Player struct {
+	entityID    uint64
+	client      *network.Client
+	characterID string
+	name        string
+
+	location Location
+	facing   maps.Direction
+	// watching lists the maps whose rooms the player is subscribed to.
+	watching []int
+
+	// chatAllowance limits how fast the player may chat.
+	chatAllowance tokenBucket
+}
+
+// EntityID returns the ID other clients know the player by.
+func (p *Player) EntityID() uint64 {
+	return p.entityID
+}
+
+// CharacterID returns the ID of the character being played.
+func (p *Player) CharacterID() string {
+	return p.characterID
+}
+
+// Name returns the character's name.
+func (p *Player) Name() string {
+	return p.name
+}
+
+// Location returns where the player currently stands.
+func (p *Player) Location() Location {
+	return p.location
+}
+
+// entity describes the player as other clients see it.
+func (p *Player) entity() *pb.Entity {
+	return &pb.Entity{
+		Id:       p.entityID,
+		Kind:     pb.EntityKind_ENTITY_KIND_PLAYER,
+		Name:     p.name,
+		Position: position(p.location),
+		Facing:   direction(p.facing),
+	}
+}
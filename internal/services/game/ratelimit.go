@@ -0,0 +1,25 @@
+package game
+
+import "time"
+
+// tokenBucket allows bursts of up to burst events, refilled at rate per
+// second. The zero value starts full on first use.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// allow takes a token at now if one is available.
+func (b *tokenBucket) allow(now time.Time, rate float64, burst int) bool {
+	if b.last.IsZero() {
+		b.tokens = float64(burst)
+	} else if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(float64(burst), b.tokens+elapsed*rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
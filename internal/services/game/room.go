@@ -0,0 +1,33 @@
+package game
+
+import "sort"
+
+// room tracks who is on a map and who receives its traffic.
+//
+// Members stand on the map. Subscribers are sent what happens on it: every
+// member, plus players on linked maps when adjacent visibility is enabled.
+type room struct {
+	members     map[uint64]*Player
+	subscribers map[uint64]*Player
+}
+
+func newRoom() *room {
+	return &room{
+		members:     make(map[uint64]*Player),
+		subscribers: make(map[uint64]*Player),
+	}
+}
+
+func (r *room) empty() bool {
+	return len(r.members) == 0 && len(r.subscribers) == 0
+}
+
+// sorted returns the players in set ordered by entity ID.
+func sorted(set map[uint64]*Player) []*Player {
+	out := make([]*Player, 0, len(set))
+	for _, p := range set {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].entityID < out[j].entityID })
+	return out
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+type RoomSuite struct {
+	suite.Suite
+	maps memoryMaps
+	out  chan network.Outbound
+	game *Game
+}
+
+func (s *RoomSuite) SetupTest() {
+	// Map 1 links east to map 2; map 3 is unrelated.
+	s.maps = memoryMaps{1: openMap(1), 2: openMap(2), 3: openMap(3)}
+	s.maps[1].Links.East = 2
+	s.maps[2].Links.West = 1
+	s.out = make(chan network.Outbound, 100)
+}
+
+// start creates the game and joins one player per map, in map order.
+func (s *RoomSuite) start(cfg Config) []*Player {
+	cfg.Spawn = Location{MapID: 1, X: 8, Y: 8}
+	s.game = New(nil, s.out, s.maps, nil, nil, cfg)
+
+	var players []*Player
+	for i, name := range []string{"one", "two", "three"} {
+		s.run(network.ClientConnected{Client: newClient(uint64(i+1), name)})
+		p := s.game.world.byCharacter[name]
+		s.game.world.place(p, Location{MapID: i + 1, X: 3, Y: 3})
+		players = append(players, p)
+	}
+	return players
+}
+
+func (s *RoomSuite) run(events ...network.Event) []network.Outbound {
+	s.game.inputs = append(s.game.inputs, events...)
+	s.game.tick(context.Background())
+	var sent []network.Outbound
+	for len(s.out) > 0 {
+		sent = append(sent, <-s.out)
+	}
+	return sent
+}
+
+func (s *RoomSuite) move(p *Player, d pb.Direction) []network.Outbound {
+	return s.run(network.ClientMessage{Client: p.client, Message: message(&pb.Move{Direction: d})})
+}
+
+func (s *RoomSuite) TestUpdatesStayInRoom() {
+	players := s.start(Config{})
+
+	sent := s.move(players[0], pb.Direction_DIRECTION_SOUTH)
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1}, sent[0].To)
+}
+
+func (s *RoomSuite) TestMembershipFollowsPlayer() {
+	players := s.start(Config{})
+	s.Equal([]*Player{players[0]}, s.game.world.audience(1))
+
+	s.game.world.place(players[0], Location{MapID: 3, X: 4, Y: 4})
+	s.Empty(s.game.world.audience(1))
+	s.Equal([]*Player{players[0], players[2]}, s.game.world.audience(3))
+
+	s.run(network.ClientDisconnected{Client: players[2].client})
+	s.Equal([]*Player{players[0]}, s.game.world.audience(3))
+}
+
+func (s *RoomSuite) TestEmptyRoomsAreDropped() {
+	players := s.start(Config{})
+
+	for _, p := range players {
+		s.run(network.ClientDisconnected{Client: p.client})
+	}
+
+	s.Empty(s.game.world.rooms)
+}
+
+func (s *RoomSuite) TestAdjacentMapsAreVisible() {
+	players := s.start(Config{AdjacentMaps: true})
+
+	sent := s.move(players[0], pb.Direction_DIRECTION_SOUTH)
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1, 2}, sent[0].To)
+	s.Equal([]*Player{players[1]}, s.game.world.visibleTo(players[0]))
+	s.Empty(s.game.world.visibleTo(players[2]))
+}
+
+func (s *RoomSuite) TestCrossingIntoAdjacentMapMoves() {
+	players := s.start(Config{AdjacentMaps: true})
+	s.game.world.place(players[0], Location{MapID: 1, X: maps.Size - 1, Y: 8})
+
+	sent := s.move(players[0], pb.Direction_DIRECTION_EAST)
+
+	// The player on map 2 already saw the mover, so it only sees a move.
+	s.Require().Len(sent, 2)
+	s.Equal([]uint64{1}, sent[0].To)
+	s.Equal(int32(2), sent[0].Message.GetMapChange().GetMapId())
+	s.Equal([]uint64{2}, sent[1].To)
+	s.Equal(int32(2), sent[1].Message.GetMove().GetPosition().GetMapId())
+}
+
+func TestRooms(t *testing.T) {
+	suite.Run(t, new(RoomSuite))
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// join puts the client's character into the world at the spawn point.
+func (g *Game) join(ctx context.Context, client *network.Client) {
+	characterID := client.CharacterID()
+	if characterID == "" {
+		slog.WarnContext(ctx, "client has no character", "client", client.ID(), "account", client.AccountID())
+		g.reject(client, pb.ErrorCode_ERROR_CODE_UNAUTHORIZED, "token does not name a character")
+		return
+	}
+	if g.characters != nil {
+		exists, err := g.characters.Exists(characterID)
+		if err != nil {
+			slog.ErrorContext(ctx, "checking character", "character", characterID, "error", err)
+			g.reject(client, pb.ErrorCode_ERROR_CODE_INTERNAL, "could not load character")
+			return
+		}
+		if !exists {
+			slog.InfoContext(ctx, "rejecting deleted character", "character", characterID, "client", client.ID())
+			g.reject(client, pb.ErrorCode_ERROR_CODE_UNAUTHORIZED, "character no longer exists")
+			return
+		}
+	}
+
+	if existing, ok := g.world.byCharacter[characterID]; ok {
+		switch g.cfg.DuplicateLogin {
+		case DuplicateLoginReject:
+			slog.InfoContext(ctx, "rejecting duplicate login", "character", characterID, "client", client.ID())
+			g.reject(client, pb.ErrorCode_ERROR_CODE_ALREADY_PLAYING, "character is already playing")
+			return
+		default:
+			slog.InfoContext(ctx, "duplicate login taking over", "character", characterID, "old_client", existing.client.ID(), "client", client.ID())
+			g.queue(network.ToClient(existing.client.ID(), disconnectMessage(pb.DisconnectReason_DISCONNECT_REASON_DUPLICATE_LOGIN, "logged in from another location")))
+			g.removePlayer(existing)
+		}
+	}
+
+	m, err := g.world.mapByID(g.cfg.Spawn.MapID)
+	if err != nil {
+		slog.ErrorContext(ctx, "spawn map unavailable", "map", g.cfg.Spawn.MapID, "error", err)
+		g.reject(client, pb.ErrorCode_ERROR_CODE_INTERNAL, "spawn map unavailable")
+		return
+	}
+
+	name := client.CharacterName()
+	if name == "" {
+		name = characterID
+	}
+	p := &Player{
+		entityID:    g.world.nextEntityID(),
+		client:      client,
+		characterID: characterID,
+		name:        name,
+		location:    g.cfg.Spawn,
+	}
+	g.world.addPlayer(p)
+	slog.InfoContext(ctx, "player joined", "character", characterID, "entity", p.entityID, "map", m.ID)
+
+	g.queue(network.ToClient(client.ID(), g.mapChange(p, m.Version)))
+	g.broadcastMap(p.location.MapID, p, message(&pb.EntitySpawn{Entity: p.entity()}))
+}
+
+// leave removes the client's player, if it has one, from the world.
+func (g *Game) leave(ctx context.Context, client *network.Client) {
+	p, ok := g.world.byClient[client.ID()]
+	if !ok {
+		return
+	}
+	slog.InfoContext(ctx, "player left", "character", p.characterID, "entity", p.entityID)
+	g.removePlayer(p)
+}
+
+// removePlayer takes p out of the world and tells everyone who could see it.
+func (g *Game) removePlayer(p *Player) {
+	g.world.removePlayer(p)
+	g.broadcastMap(p.location.MapID, p, message(&pb.EntityDespawn{EntityId: p.entityID}))
+}
+
+// reject tells the client why it cannot play and asks the network to close it.
+func (g *Game) reject(client *network.Client, code pb.ErrorCode, text string) {
+	g.queue(network.ToClient(client.ID(), errorMessage(code, text)))
+	g.queue(network.ToClient(client.ID(), disconnectMessage(pb.DisconnectReason_DISCONNECT_REASON_KICKED, text)))
+}
+
+// mapChange describes p's current map to p.
+func (g *Game) mapChange(p *Player, version int) *pb.GameMessage {
+	var entities []*pb.Entity
+	for _, other := range g.world.visibleTo(p) {
+		entities = append(entities, other.entity())
+	}
+	return message(&pb.MapChange{
+		MapId:      int32(p.location.MapID),
+		MapVersion: int32(version),
+		EntityId:   p.entityID,
+		Position:   position(p.location),
+		Entities:   entities,
+	})
+}
+
+// broadcastMap sends msg to the map's room except skip.
+func (g *Game) broadcastMap(mapID int, skip *Player, msg *pb.GameMessage) {
+	g.send(g.world.audience(mapID), skip, msg)
+}
+
+// send queues msg for every player in to except skip.
+func (g *Game) send(to []*Player, skip *Player, msg *pb.GameMessage) {
+	var ids []uint64
+	for _, p := range to {
+		if p != skip {
+			ids = append(ids, p.client.ID())
+		}
+	}
+	if len(ids) > 0 {
+		g.queue(network.ToClients(ids, msg))
+	}
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+type SessionSuite struct {
+	suite.Suite
+	out  chan network.Outbound
+	game *Game
+}
+
+func (s *SessionSuite) SetupTest() {
+	s.out = make(chan network.Outbound, 100)
+	s.game = New(nil, s.out, memoryMaps{1: maps.NewMap(1, "Spawn")}, nil, nil, Config{
+		Spawn: Location{MapID: 1, X: 8, Y: 8},
+	})
+}
+
+// run applies events as a single tick and returns what it sent.
+func (s *SessionSuite) run(events ...network.Event) []network.Outbound {
+	s.game.inputs = append(s.game.inputs, events...)
+	s.game.tick(context.Background())
+	var sent []network.Outbound
+	for len(s.out) > 0 {
+		sent = append(sent, <-s.out)
+	}
+	return sent
+}
+
+func (s *SessionSuite) TestJoinPlacesPlayerAtSpawn() {
+	c := newClient(1, "hero")
+	sent := s.run(network.ClientConnected{Client: c})
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1}, sent[0].To)
+	change := sent[0].Message.GetMapChange()
+	s.Require().NotNil(change)
+	s.Equal(int32(1), change.MapId)
+	s.Equal(int32(8), change.Position.X)
+	s.Equal(int32(8), change.Position.Y)
+	s.Empty(change.Entities)
+
+	p := s.game.world.byCharacter["hero"]
+	s.Require().NotNil(p)
+	s.Equal(Location{MapID: 1, X: 8, Y: 8}, p.Location())
+	s.Equal(change.EntityId, p.EntityID())
+}
+
+func (s *SessionSuite) TestJoinUsesCharacterName() {
+	c := network.NewClient(1, nil, &auth.Claims{CharacterID: "c-1", CharacterName: "Hero"})
+	s.run(network.ClientConnected{Client: c})
+
+	s.Equal("Hero", s.game.world.byCharacter["c-1"].Name())
+}
+
+func (s *SessionSuite) TestJoinIsBroadcastToMap() {
+	s.run(network.ClientConnected{Client: newClient(1, "hero")})
+	sent := s.run(network.ClientConnected{Client: newClient(2, "sidekick")})
+
+	s.Require().Len(sent, 2)
+	change := sent[0].Message.GetMapChange()
+	s.Require().NotNil(change)
+	s.Require().Len(change.Entities, 1)
+	s.Equal("hero", change.Entities[0].Name)
+
+	s.Equal([]uint64{1}, sent[1].To)
+	s.Equal("sidekick", sent[1].Message.GetEntitySpawn().GetEntity().GetName())
+}
+
+func (s *SessionSuite) TestDisconnectDespawns() {
+	hero := newClient(1, "hero")
+	s.run(network.ClientConnected{Client: hero})
+	s.run(network.ClientConnected{Client: newClient(2, "sidekick")})
+	entity := s.game.world.byCharacter["hero"].EntityID()
+
+	sent := s.run(network.ClientDisconnected{Client: hero})
+
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{2}, sent[0].To)
+	s.Equal(entity, sent[0].Message.GetEntityDespawn().GetEntityId())
+	s.NotContains(s.game.world.byCharacter, "hero")
+	s.Len(s.game.world.players, 1)
+}
+
+func (s *SessionSuite) TestDuplicateLoginTakesOver() {
+	old := newClient(1, "hero")
+	s.run(network.ClientConnected{Client: old})
+
+	sent := s.run(network.ClientConnected{Client: newClient(2, "hero")})
+
+	s.Require().Len(sent, 2)
+	s.Equal([]uint64{1}, sent[0].To)
+	s.Equal(pb.DisconnectReason_DISCONNECT_REASON_DUPLICATE_LOGIN, sent[0].Message.GetDisconnect().GetReason())
+	s.Equal([]uint64{2}, sent[1].To)
+	s.NotNil(sent[1].Message.GetMapChange())
+	s.Len(s.game.world.players, 1)
+
+	// The old connection closing later must not remove the new session.
+	s.run(network.ClientDisconnected{Client: old})
+	s.Contains(s.game.world.byCharacter, "hero")
+}
+
+func (s *SessionSuite) TestDuplicateLoginRejected() {
+	s.game.cfg.DuplicateLogin = DuplicateLoginReject
+	s.run(network.ClientConnected{Client: newClient(1, "hero")})
+
+	sent := s.run(network.ClientConnected{Client: newClient(2, "hero")})
+
+	s.Require().Len(sent, 2)
+	s.Equal(pb.ErrorCode_ERROR_CODE_ALREADY_PLAYING, sent[0].Message.GetError().GetCode())
+	s.NotNil(sent[1].Message.GetDisconnect())
+	s.Equal(uint64(1), s.game.world.byCharacter["hero"].client.ID())
+}
+
+func (s *SessionSuite) TestJoinWithoutCharacterRejected() {
+	sent := s.run(network.ClientConnected{Client: newClient(1, "")})
+
+	s.Require().Len(sent, 2)
+	s.Equal(pb.ErrorCode_ERROR_CODE_UNAUTHORIZED, sent[0].Message.GetError().GetCode())
+	s.Empty(s.game.world.players)
+}
+
+func (s *SessionSuite) TestDeletedCharacterRejected() {
+	s.game.characters = memoryCharacters{"hero": {}}
+
+	sent := s.run(network.ClientConnected{Client: newClient(1, "villain")})
+	s.Require().Len(sent, 2)
+	s.Equal(pb.ErrorCode_ERROR_CODE_UNAUTHORIZED, sent[0].Message.GetError().GetCode())
+	s.Empty(s.game.world.players)
+
+	s.run(network.ClientConnected{Client: newClient(2, "hero")})
+	s.NotNil(s.game.world.byCharacter["hero"])
+}
+
+func (s *SessionSuite) TestMissingSpawnMapRejected() {
+	s.game.cfg.Spawn.MapID = 99
+	sent := s.run(network.ClientConnected{Client: newClient(1, "hero")})
+
+	s.Require().Len(sent, 2)
+	s.Equal(pb.ErrorCode_ERROR_CODE_INTERNAL, sent[0].Message.GetError().GetCode())
+	s.Empty(s.game.world.players)
+}
+
+func TestSession(t *testing.T) {
+	suite.Run(t, new(SessionSuite))
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+	"github.com/Odyssey-Classic/server/internal/game/scripts"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+// Triggers runs the scripts named by tile triggers.
+type Triggers interface {
+	Run(name string, t scripts.Trigger, host scripts.Host) error
+}
+
+// fireTrigger runs the trigger script, if any, of the tile at loc on behalf
+// of p. Script failures are logged and otherwise ignored.
+func (g *Game) fireTrigger(ctx context.Context, p *Player, loc Location, event scripts.Event) {
+	if g.triggers == nil {
+		return
+	}
+	m, err := g.world.mapByID(loc.MapID)
+	if err != nil {
+		return
+	}
+	tile, err := m.GetTile(loc.X, loc.Y)
+	if err != nil || tile.Trigger == "" {
+		return
+	}
+
+	t := scripts.Trigger{Event: event, Player: p.name, MapID: loc.MapID, X: loc.X, Y: loc.Y}
+	host := &triggerHost{ctx: ctx, game: g, player: p, tile: loc}
+	if err := g.triggers.Run(tile.Trigger, t, host); err != nil {
+		slog.WarnContext(ctx, "trigger failed", "script", tile.Trigger, "event", event, "map", loc.MapID, "x", loc.X, "y", loc.Y, "error", err)
+	}
+}
+
+// interact fires the interact trigger of the tile p is facing.
+func (g *Game) interact(ctx context.Context, p *Player) {
+	dx, dy := p.facing.Delta()
+	target := Location{MapID: p.location.MapID, X: p.location.X + dx, Y: p.location.Y + dy}
+	if !maps.InBounds(target.X, target.Y) {
+		return
+	}
+	g.fireTrigger(ctx, p, target, scripts.Interact)
+}
+
+// triggerHost is the scripts.Host for a single trigger invocation.
+type triggerHost struct {
+	ctx    context.Context
+	game   *Game
+	player *Player
+	tile   Location
+}
+
+func (h *triggerHost) Message(text string) error {
+	h.game.queue(network.ToClient(h.player.client.ID(), message(&pb.Chat{Text: text, Scope: pb.ChatScope_CHAT_SCOPE_SYSTEM})))
+	return nil
+}
+
+func (h *triggerHost) Warp(mapID, x, y int) error {
+	return h.game.warp(h.ctx, h.player, maps.WarpDestination{MapID: mapID, X: x, Y: y})
+}
+
+// SetTileAttribute changes the live copy of the map only; it is not saved.
+// Players in the map's room are told about the change.
+func (h *triggerHost) SetTileAttribute(key, value string) error {
+	m, err := h.game.world.mapByID(h.tile.MapID)
+	if err != nil {
+		return err
+	}
+	tile, err := m.GetTile(h.tile.X, h.tile.Y)
+	if err != nil {
+		return err
+	}
+	if tile.Attributes == nil {
+		tile.Attributes = make(map[string]string)
+	}
+	tile.Attributes[key] = value
+
+	attrs := make(map[string]string, len(tile.Attributes))
+	for k, v := range tile.Attributes {
+		attrs[k] = v
+	}
+	h.game.broadcastMap(h.tile.MapID, nil, message(&pb.TileChange{
+		Position:   position(h.tile),
+		Attributes: attrs,
+	}))
+	return nil
+}
+
+// GiveItem saves the items to the player's character straight away, so they
+// survive a disconnect, and tells the player what they received.
+func (h *triggerHost) GiveItem(itemID string, count int) error {
+	if itemID == "" {
+		return errors.New("item id is required")
+	}
+	if h.game.characters == nil {
+		return errors.New("characters are not stored by this server")
+	}
+	if err := h.game.characters.GiveItem(h.player.characterID, itemID, count); err != nil {
+		return err
+	}
+	slog.InfoContext(h.ctx, "item given", "character", h.player.characterID, "item", itemID, "count", count)
+	return h.Message(fmt.Sprintf("You received %d %s.", count, itemID))
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/game/scripts"
+	"github.com/Odyssey-Classic/server/internal/services/network"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+type TriggerSuite struct {
+	suite.Suite
+	dir        string
+	maps       memoryMaps
+	characters memoryCharacters
+	out        chan network.Outbound
+	game       *Game
+	hero       *Player
+}
+
+func (s *TriggerSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	engine, err := scripts.New(s.dir, scripts.DefaultLimits)
+	s.Require().NoError(err)
+
+	s.maps = memoryMaps{1: openMap(1), 2: openMap(2)}
+	s.characters = memoryCharacters{"hero": {}}
+	s.out = make(chan network.Outbound, 100)
+	s.game = New(nil, s.out, s.maps, engine, s.characters, Config{Spawn: Location{MapID: 1, X: 8, Y: 8}})
+	s.run(network.ClientConnected{Client: newClient(1, "hero")})
+	s.hero = s.game.world.byCharacter["hero"]
+}
+
+func (s *TriggerSuite) script(name, src string) {
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, name+scripts.Extension), []byte(src), 0o644))
+}
+
+func (s *TriggerSuite) run(events ...network.Event) []network.Outbound {
+	s.game.inputs = append(s.game.inputs, events...)
+	s.game.tick(context.Background())
+	var sent []network.Outbound
+	for len(s.out) > 0 {
+		sent = append(sent, <-s.out)
+	}
+	return sent
+}
+
+func (s *TriggerSuite) send(payload any) []network.Outbound {
+	return s.run(network.ClientMessage{Client: s.hero.client, Message: message(payload)})
+}
+
+// chats returns the text of every chat message in sent.
+func chats(sent []network.Outbound) []string {
+	var out []string
+	for _, o := range sent {
+		if c := o.Message.GetChat(); c != nil {
+			out = append(out, c.Text)
+		}
+	}
+	return out
+}
+
+func (s *TriggerSuite) TestEnterAndLeave() {
+	s.script("greet", `
+def on_enter(t):
+    game.message("enter %d,%d" % (t.x, t.y))
+
+def on_leave(t):
+    game.message("leave %d,%d" % (t.x, t.y))
+`)
+	s.maps[1].Tiles[8][8].Trigger = "greet"
+	s.maps[1].Tiles[9][8].Trigger = "greet"
+
+	sent := s.send(&pb.Move{Direction: pb.Direction_DIRECTION_EAST})
+
+	s.Equal([]string{"leave 8,8", "enter 9,8"}, chats(sent))
+}
+
+func (s *TriggerSuite) TestInteractWithFacedTile() {
+	s.script("chest", `
+def on_interact(t):
+    game.set_tile_attribute("opened", "true")
+`)
+	s.maps[1].Tiles[8][7].Trigger = "chest" // North of the spawn, the default facing.
+
+	sent := s.send(&pb.Interact{})
+
+	s.Equal("true", s.maps[1].Tiles[8][7].Attributes["opened"])
+	s.Require().Len(sent, 1)
+	s.Equal([]uint64{1}, sent[0].To)
+	change := sent[0].Message.GetTileChange()
+	s.Require().NotNil(change)
+	s.Equal(int32(8), change.Position.X)
+	s.Equal(int32(7), change.Position.Y)
+	s.Equal(map[string]string{"opened": "true"}, change.Attributes)
+}
+
+func (s *TriggerSuite) TestInteractWithBlockedTile() {
+	s.script("lever", "def on_interact(t):\n    game.message(\"pulled %d,%d\" % (t.x, t.y))\n")
+	s.maps[1].Tiles[9][8].Trigger = "lever"
+	s.maps[1].Tiles[9][8].Passable = false
+
+	sent := s.send(&pb.Move{Direction: pb.Direction_DIRECTION_EAST})
+	s.Equal(Location{MapID: 1, X: 8, Y: 8}, s.hero.location)
+	s.Require().NotEmpty(sent)
+	s.Equal(pb.Direction_DIRECTION_EAST, sent[len(sent)-1].Message.GetMove().GetDirection())
+
+	sent = s.send(&pb.Interact{})
+
+	s.Equal([]string{"pulled 9,8"}, chats(sent))
+}
+
+func (s *TriggerSuite) TestGiveItem() {
+	s.script("chest", `
+def on_interact(t):
+    game.give_item("gold", 10)
+    game.give_item("key")
+`)
+	s.maps[1].Tiles[8][7].Trigger = "chest"
+
+	sent := s.send(&pb.Interact{})
+
+	s.Equal(map[string]int{"gold": 10, "key": 1}, s.characters["hero"])
+	s.Equal([]string{"You received 10 gold.", "You received 1 key."}, chats(sent))
+}
+
+func (s *TriggerSuite) TestScriptWarp() {
+	s.script("portal", "def on_enter(t):\n    game.warp(2, 3, 4)\n")
+	s.maps[1].Tiles[9][8].Trigger = "portal"
+
+	sent := s.send(&pb.Move{Direction: pb.Direction_DIRECTION_EAST})
+
+	s.Equal(Location{MapID: 2, X: 3, Y: 4}, s.hero.location)
+	s.Equal(int32(2), sent[len(sent)-1].Message.GetMapChange().GetMapId())
+}
+
+func (s *TriggerSuite) TestFailingScriptDoesNotBreakMove() {
+	s.script("broken", "def on_enter(t):\n    game.warp(99, 0, 0)\n")
+	s.maps[1].Tiles[9][8].Trigger = "broken"
+
+	s.send(&pb.Move{Direction: pb.Direction_DIRECTION_EAST})
+
+	s.Equal(Location{MapID: 1, X: 9, Y: 8}, s.hero.location)
+}
+
+func TestTriggers(t *testing.T) {
+	suite.Run(t, new(TriggerSuite))
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Odyssey-Classic/server/internal/game/maps"
+)
+
+// MapSource provides the maps the world is built from.
+type MapSource interface {
+	Get(id int) (*maps.Map, error)
+}
+
+// Characters is the game's view of the saved characters. Tokens outlive the
+// characters they name, so joins are checked against it.
+type Characters interface {
+	Exists(id string) (bool, error)
+	// GiveItem adds count of an item to the character's saved inventory.
+	GiveItem(id, itemID string, count int) error
+}
+
+// world is the state of the simulation. It is owned by the game loop goroutine.
+type world struct {
+	source MapSource
+	maps   map[int]*maps.Map
+	// adjacent subscribes players to the maps linked from their own.
+	adjacent bool
+
+	lastEntityID uint64
+	players      map[uint64]*Player
+	byClient     map[uint64]*Player
+	byCharacter  map[string]*Player
+	rooms        map[int]*room
+}
+
+func newWorld(source MapSource, adjacent bool) *world {
+	return &world{
+		source:      source,
+		maps:        make(map[int]*maps.Map),
+		adjacent:    adjacent,
+		players:     make(map[uint64]*Player),
+		byClient:    make(map[uint64]*Player),
+		byCharacter: make(map[string]*Player),
+		rooms:       make(map[int]*room),
+	}
+}
+
+// mapByID returns the map with the given ID, loading it on first use.
+func (w *world) mapByID(id int) (*maps.Map, error) {
+	if m, ok := w.maps[id]; ok {
+		return m, nil
+	}
+	m, err := w.source.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("loading map %d: %w", id, err)
+	}
+	w.maps[id] = m
+	return m, nil
+}
+
+// nextEntityID returns a new, never before used entity ID.
+func (w *world) nextEntityID() uint64 {
+	w.lastEntityID++
+	return w.lastEntityID
+}
+
+// addPlayer puts p into the world at its current location.
+func (w *world) addPlayer(p *Player) {
+	w.players[p.entityID] = p
+	w.byClient[p.client.ID()] = p
+	w.byCharacter[p.characterID] = p
+	w.enterRooms(p)
+}
+
+func (w *world) removePlayer(p *Player) {
+	w.leaveRooms(p)
+	delete(w.players, p.entityID)
+	if w.byClient[p.client.ID()] == p {
+		delete(w.byClient, p.client.ID())
+	}
+	if w.byCharacter[p.characterID] == p {
+		delete(w.byCharacter, p.characterID)
+	}
+}
+
+// place moves p to loc, switching rooms when the map changes.
+func (w *world) place(p *Player, loc Location) {
+	if loc.MapID == p.location.MapID {
+		p.location = loc
+		return
+	}
+	w.leaveRooms(p)
+	p.location = loc
+	w.enterRooms(p)
+}
+
+func (w *world) room(mapID int) *room {
+	r, ok := w.rooms[mapID]
+	if !ok {
+		r = newRoom()
+		w.rooms[mapID] = r
+	}
+	return r
+}
+
+// enterRooms makes p a member of its map's room and subscribes it to every
+// room it can see.
+func (w *world) enterRooms(p *Player) {
+	w.room(p.location.MapID).members[p.entityID] = p
+	p.watching = w.watchable(p.location.MapID)
+	for _, id := range p.watching {
+		w.room(id).subscribers[p.entityID] = p
+	}
+}
+
+// leaveRooms undoes enterRooms.
+func (w *world) leaveRooms(p *Player) {
+	if r, ok := w.rooms[p.location.MapID]; ok {
+		delete(r.members, p.entityID)
+	}
+	for _, id := range p.watching {
+		if r, ok := w.rooms[id]; ok {
+			delete(r.subscribers, p.entityID)
+			if r.empty() {
+				delete(w.rooms, id)
+			}
+		}
+	}
+	p.watching = nil
+}
+
+// watchable returns the maps a player on mapID receives traffic for: the map
+// itself and, with adjacent visibility, the maps linked from it.
+func (w *world) watchable(mapID int) []int {
+	ids := []int{mapID}
+	if !w.adjacent {
+		return ids
+	}
+	m, err := w.mapByID(mapID)
+	if err != nil {
+		return ids
+	}
+	for _, d := range []maps.Direction{maps.North, maps.East, maps.South, maps.West} {
+		if link := m.Links.Get(d); link != 0 && link != mapID {
+			if _, err := w.mapByID(link); err == nil {
+				ids = append(ids, link)
+			}
+		}
+	}
+	return ids
+}
+
+// audience returns the players that receive traffic for mapID, ordered by
+// entity ID.
+func (w *world) audience(mapID int) []*Player {
+	r, ok := w.rooms[mapID]
+	if !ok {
+		return nil
+	}
+	return sorted(r.subscribers)
+}
+
+// visibleTo returns the other players p can see, ordered by entity ID.
+func (w *world) visibleTo(p *Player) []*Player {
+	set := make(map[uint64]*Player)
+	for _, id := range p.watching {
+		if r, ok := w.rooms[id]; ok {
+			for eid, other := range r.members {
+				if other != p {
+					set[eid] = other
+				}
+			}
+		}
+	}
+	return sorted(set)
+}
+
+// everyone returns every player in the world, ordered by entity ID.
+func (w *world) everyone() []*Player {
+	return sorted(w.players)
+}
+
+// playerNamed returns the player whose character is called name, ignoring
+// case, if it is in the world.
+func (w *world) playerNamed(name string) *Player {
+	for _, p := range w.players {
+		if strings.EqualFold(p.name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// occupant returns the player standing at loc, if any.
+func (w *world) occupant(loc Location) *Player {
+	r, ok := w.rooms[loc.MapID]
+	if !ok {
+		return nil
+	}
+	for _, p := range r.members {
+		if p.location == loc {
+			return p
+		}
+	}
+	return nil
+}
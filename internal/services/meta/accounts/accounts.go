@@ -0,0 +1,34 @@
+// Package accounts stores player accounts and their password hashes.
+package accounts
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrExists is returned when creating an account whose username is taken.
+	ErrExists = errors.New("username is taken")
+	// ErrNotFound is returned when no account matches.
+	ErrNotFound = errors.New("account not found")
+)
+
+// Account is a player's login.
+type Account struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash []byte    `json:"password_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Store persists accounts. Usernames are unique, ignoring case.
+type Store interface {
+	// Create adds an account with the given username and password.
+	Create(username, password string) (*Account, error)
+
+	// Authenticate returns the account for username if password matches.
+	Authenticate(username, password string) (*Account, error)
+
+	// Get retrieves an account by its ID.
+	Get(id string) (*Account, error)
+}
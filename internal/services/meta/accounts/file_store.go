@@ -0,0 +1,117 @@
+package accounts
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+)
+
+// FileStore persists accounts as JSON files named by account ID.
+type FileStore struct {
+	root string
+
+	mu         sync.RWMutex
+	byUsername map[string]string // lower case username -> ID
+}
+
+// NewFileStore opens the accounts stored under root, creating it if needed.
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	s := &FileStore{root: root, byUsername: make(map[string]string)}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		var a Account
+		if err := data.ReadJSON(filepath.Join(root, e.Name()), &a); err != nil {
+			return nil, err
+		}
+		s.byUsername[strings.ToLower(a.Username)] = a.ID
+	}
+	return s, nil
+}
+
+func (s *FileStore) pathFor(id string) string {
+	return filepath.Join(s.root, id+".json")
+}
+
+func (s *FileStore) Create(username, password string) (*Account, error) {
+	if err := auth.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	if err := auth.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	id, err := auth.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(username)
+	if _, ok := s.byUsername[key]; ok {
+		return nil, ErrExists
+	}
+	a := &Account{
+		ID:           id,
+		Username:     username,
+		PasswordHash: hash,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := data.WriteJSON(s.pathFor(id), a); err != nil {
+		return nil, err
+	}
+	s.byUsername[key] = id
+	return a, nil
+}
+
+func (s *FileStore) Authenticate(username, password string) (*Account, error) {
+	s.mu.RLock()
+	id, ok := s.byUsername[strings.ToLower(username)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	a, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.CheckPassword(a.PasswordHash, password); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *FileStore) Get(id string) (*Account, error) {
+	if !auth.ValidID(id) {
+		return nil, ErrNotFound
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var a Account
+	if err := data.ReadJSON(s.pathFor(id), &a); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,80 @@
+package accounts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+)
+
+type FileStoreSuite struct {
+	suite.Suite
+	dir   string
+	store *FileStore
+}
+
+func (s *FileStoreSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	store, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	s.store = store
+}
+
+func (s *FileStoreSuite) TestCreateAndAuthenticate() {
+	a, err := s.store.Create("Hero_1", "correct horse")
+	s.Require().NoError(err)
+	s.True(auth.ValidID(a.ID))
+	s.NotContains(string(a.PasswordHash), "correct horse")
+
+	got, err := s.store.Authenticate("hero_1", "correct horse")
+	s.Require().NoError(err)
+	s.Equal(a.ID, got.ID)
+
+	_, err = s.store.Authenticate("hero_1", "wrong horse")
+	s.ErrorIs(err, auth.ErrWrongPassword)
+	_, err = s.store.Authenticate("nobody", "correct horse")
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *FileStoreSuite) TestUsernameUniqueIgnoringCase() {
+	_, err := s.store.Create("hero", "password1")
+	s.Require().NoError(err)
+
+	_, err = s.store.Create("HERO", "password2")
+	s.ErrorIs(err, ErrExists)
+}
+
+func (s *FileStoreSuite) TestValidation() {
+	_, err := s.store.Create("ab", "password1")
+	s.ErrorIs(err, auth.ErrInvalidUsername)
+	_, err = s.store.Create("../etc", "password1")
+	s.ErrorIs(err, auth.ErrInvalidUsername)
+	_, err = s.store.Create("hero", "short")
+	s.ErrorIs(err, auth.ErrInvalidPassword)
+	_, err = s.store.Create("hero", strings.Repeat("x", auth.MaxPasswordLength+1))
+	s.ErrorIs(err, auth.ErrInvalidPassword)
+}
+
+func (s *FileStoreSuite) TestPersists() {
+	a, err := s.store.Create("hero", "password1")
+	s.Require().NoError(err)
+
+	reopened, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	got, err := reopened.Get(a.ID)
+	s.Require().NoError(err)
+	s.Equal("hero", got.Username)
+	_, err = reopened.Create("Hero", "password1")
+	s.ErrorIs(err, ErrExists)
+}
+
+func (s *FileStoreSuite) TestGetRejectsBadIDs() {
+	_, err := s.store.Get("../../secret")
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func TestFileStore(t *testing.T) {
+	suite.Run(t, new(FileStoreSuite))
+}
@@ -0,0 +1,242 @@
+package meta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/services/meta/accounts"
+	"github.com/Odyssey-Classic/server/internal/services/meta/characters"
+	"github.com/Odyssey-Classic/server/internal/services/utils"
+)
+
+// API serves account and character management to players before they
+// connect to the Network service.
+type API struct {
+	router     chi.Router
+	accounts   accounts.Store
+	characters characters.Store
+	issuer     *auth.Issuer
+	verifier   auth.Verifier
+}
+
+// Credentials is the body of POST /accounts and POST /sessions.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// AccountResponse describes an account without its password hash.
+type AccountResponse struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TokenResponse carries a signed bearer token.
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateCharacterRequest is the body of POST /characters.
+type CreateCharacterRequest struct {
+	Name string `json:"name"`
+}
+
+type accountKey struct{}
+
+func newAPI(accountStore accounts.Store, characterStore characters.Store, issuer *auth.Issuer, verifier auth.Verifier) *API {
+	a := &API{
+		router:     chi.NewRouter(),
+		accounts:   accountStore,
+		characters: characterStore,
+		issuer:     issuer,
+		verifier:   verifier,
+	}
+
+	a.router.Use(middleware.RequestID)
+	a.router.Use(middleware.RealIP)
+	a.router.Use(middleware.Recoverer)
+	a.router.Use(middleware.SetHeader("Content-Type", "application/json"))
+
+	a.router.Post("/accounts", a.register)
+	a.router.Post("/sessions", a.login)
+	a.router.Route("/characters", func(r chi.Router) {
+		r.Use(a.authenticate)
+		r.Get("/", a.listCharacters)
+		r.Post("/", a.createCharacter)
+		r.Delete("/{id}", a.deleteCharacter)
+		r.Post("/{id}/token", a.characterToken)
+	})
+	return a
+}
+
+// ServeHTTP implements http.Handler interface
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.router.ServeHTTP(w, r)
+}
+
+// authenticate requires a bearer token and stores its account ID in the
+// request context.
+func (a *API) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			utils.WriteError(w, http.StatusUnauthorized, "Missing bearer token")
+			return
+		}
+		claims, err := a.verifier.Verify(token)
+		if err != nil {
+			utils.WriteError(w, http.StatusUnauthorized, "Invalid bearer token")
+			return
+		}
+		ctx := context.WithValue(r.Context(), accountKey{}, claims.AccountID())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func accountID(r *http.Request) string {
+	id, _ := r.Context().Value(accountKey{}).(string)
+	return id
+}
+
+// register handles POST /accounts - Create an account
+func (a *API) register(w http.ResponseWriter, r *http.Request) {
+	var req Credentials
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	acct, err := a.accounts.Create(req.Username, req.Password)
+	switch {
+	case errors.Is(err, auth.ErrInvalidUsername), errors.Is(err, auth.ErrInvalidPassword):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, accounts.ErrExists):
+		utils.WriteError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		slog.Error("creating account", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create account")
+		return
+	}
+	slog.Info("account created", "account", acct.ID, "username", acct.Username)
+	utils.WriteJSON(w, http.StatusCreated, AccountResponse{ID: acct.ID, Username: acct.Username, CreatedAt: acct.CreatedAt})
+}
+
+// login handles POST /sessions - Exchange credentials for an account token
+func (a *API) login(w http.ResponseWriter, r *http.Request) {
+	var req Credentials
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	acct, err := a.accounts.Authenticate(req.Username, req.Password)
+	switch {
+	case errors.Is(err, accounts.ErrNotFound), errors.Is(err, auth.ErrWrongPassword):
+		utils.WriteError(w, http.StatusUnauthorized, "Invalid username or password")
+		return
+	case err != nil:
+		slog.Error("authenticating account", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+	a.writeToken(w, acct.ID, nil)
+}
+
+// listCharacters handles GET /characters - List the account's characters
+func (a *API) listCharacters(w http.ResponseWriter, r *http.Request) {
+	list, err := a.characters.List(accountID(r))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to list characters")
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, list)
+}
+
+// createCharacter handles POST /characters - Create a character
+func (a *API) createCharacter(w http.ResponseWriter, r *http.Request) {
+	var req CreateCharacterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	c, err := a.characters.Create(accountID(r), strings.TrimSpace(req.Name))
+	switch {
+	case errors.Is(err, characters.ErrInvalidName):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, characters.ErrExists), errors.Is(err, characters.ErrLimitReached):
+		utils.WriteError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		slog.Error("creating character", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to create character")
+		return
+	}
+	slog.Info("character created", "account", c.AccountID, "character", c.ID, "name", c.Name)
+	utils.WriteJSON(w, http.StatusCreated, c)
+}
+
+// deleteCharacter handles DELETE /characters/{id} - Delete a character
+func (a *API) deleteCharacter(w http.ResponseWriter, r *http.Request) {
+	c, ok := a.ownedCharacter(w, r)
+	if !ok {
+		return
+	}
+	if err := a.characters.Delete(c.ID); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to delete character")
+		return
+	}
+	slog.Info("character deleted", "account", c.AccountID, "character", c.ID, "name", c.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// characterToken handles POST /characters/{id}/token - Issue the token used
+// to connect to the Network service as the character
+func (a *API) characterToken(w http.ResponseWriter, r *http.Request) {
+	c, ok := a.ownedCharacter(w, r)
+	if !ok {
+		return
+	}
+	a.writeToken(w, c.AccountID, c)
+}
+
+// ownedCharacter loads the character named in the URL, answering 404 when it
+// does not exist or belongs to another account.
+func (a *API) ownedCharacter(w http.ResponseWriter, r *http.Request) (*characters.Character, bool) {
+	c, err := a.characters.Get(chi.URLParam(r, "id"))
+	if errors.Is(err, characters.ErrNotFound) || (err == nil && c.AccountID != accountID(r)) {
+		utils.WriteError(w, http.StatusNotFound, "Character not found")
+		return nil, false
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to load character")
+		return nil, false
+	}
+	return c, true
+}
+
+// writeToken issues a token for the account and, when c is set, character.
+func (a *API) writeToken(w http.ResponseWriter, accountID string, c *characters.Character) {
+	var characterID, name string
+	if c != nil {
+		characterID, name = c.ID, c.Name
+	}
+	token, expires, err := a.issuer.Issue(accountID, characterID, name)
+	if err != nil {
+		slog.Error("issuing token", "error", err)
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to issue token")
+		return
+	}
+	utils.WriteJSON(w, http.StatusOK, TokenResponse{Token: token, ExpiresAt: expires})
+}
@@ -0,0 +1,145 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+	"github.com/Odyssey-Classic/server/internal/services/meta/characters"
+)
+
+type MetaAPITestSuite struct {
+	suite.Suite
+	meta     *Meta
+	verifier auth.Verifier
+}
+
+func (s *MetaAPITestSuite) SetupTest() {
+	secret := []byte("test-secret")
+	s.verifier = auth.NewHMACVerifier(secret, "")
+	m, err := New("", data.NewOSRoot(s.T().TempDir()), auth.NewHMACIssuer(secret, "", time.Hour), s.verifier)
+	s.Require().NoError(err)
+	s.meta = m
+}
+
+func (s *MetaAPITestSuite) do(method, path, token string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		s.Require().NoError(json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.meta.api.ServeHTTP(w, req)
+	return w
+}
+
+// login registers an account and returns its account token.
+func (s *MetaAPITestSuite) login(username string) string {
+	creds := Credentials{Username: username, Password: "password1"}
+	s.Require().Equal(http.StatusCreated, s.do(http.MethodPost, "/accounts", "", creds).Code)
+
+	w := s.do(http.MethodPost, "/sessions", "", creds)
+	s.Require().Equal(http.StatusOK, w.Code)
+	var resp TokenResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+	return resp.Token
+}
+
+func (s *MetaAPITestSuite) createCharacter(token, name string) *characters.Character {
+	w := s.do(http.MethodPost, "/characters", token, CreateCharacterRequest{Name: name})
+	s.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var c characters.Character
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&c))
+	return &c
+}
+
+func (s *MetaAPITestSuite) TestJoinFlow() {
+	token := s.login("player")
+	c := s.createCharacter(token, "Hero")
+
+	w := s.do(http.MethodGet, "/characters", token, nil)
+	s.Equal(http.StatusOK, w.Code)
+	var list []characters.Character
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&list))
+	s.Require().Len(list, 1)
+	s.Equal(c.ID, list[0].ID)
+
+	w = s.do(http.MethodPost, "/characters/"+c.ID+"/token", token, nil)
+	s.Require().Equal(http.StatusOK, w.Code)
+	var resp TokenResponse
+	s.Require().NoError(json.NewDecoder(w.Body).Decode(&resp))
+
+	// The token is the one the Network service accepts.
+	claims, err := s.verifier.Verify(resp.Token)
+	s.Require().NoError(err)
+	s.Equal(c.AccountID, claims.AccountID())
+	s.Equal(c.ID, claims.CharacterID)
+	s.Equal("Hero", claims.CharacterName)
+}
+
+func (s *MetaAPITestSuite) TestRegisterErrors() {
+	s.login("player")
+
+	w := s.do(http.MethodPost, "/accounts", "", Credentials{Username: "PLAYER", Password: "password1"})
+	s.Equal(http.StatusConflict, w.Code)
+	w = s.do(http.MethodPost, "/accounts", "", Credentials{Username: "x", Password: "password1"})
+	s.Equal(http.StatusBadRequest, w.Code)
+	w = s.do(http.MethodPost, "/accounts", "", Credentials{Username: "other", Password: "short"})
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *MetaAPITestSuite) TestLoginFailures() {
+	s.login("player")
+
+	w := s.do(http.MethodPost, "/sessions", "", Credentials{Username: "player", Password: "wrong-password"})
+	s.Equal(http.StatusUnauthorized, w.Code)
+	w = s.do(http.MethodPost, "/sessions", "", Credentials{Username: "nobody", Password: "password1"})
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (s *MetaAPITestSuite) TestCharactersRequireToken() {
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, "/characters", "", nil).Code)
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, "/characters", "not-a-token", nil).Code)
+}
+
+func (s *MetaAPITestSuite) TestCharacterErrors() {
+	token := s.login("player")
+	s.createCharacter(token, "Hero")
+
+	w := s.do(http.MethodPost, "/characters", token, CreateCharacterRequest{Name: "hero"})
+	s.Equal(http.StatusConflict, w.Code)
+	w = s.do(http.MethodPost, "/characters", token, CreateCharacterRequest{Name: "!"})
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *MetaAPITestSuite) TestOtherAccountsCharactersAreHidden() {
+	owner := s.login("owner")
+	c := s.createCharacter(owner, "Hero")
+	intruder := s.login("intruder")
+
+	s.Equal(http.StatusNotFound, s.do(http.MethodDelete, "/characters/"+c.ID, intruder, nil).Code)
+	s.Equal(http.StatusNotFound, s.do(http.MethodPost, "/characters/"+c.ID+"/token", intruder, nil).Code)
+
+	s.Equal(http.StatusNoContent, s.do(http.MethodDelete, "/characters/"+c.ID, owner, nil).Code)
+	s.Equal(http.StatusNotFound, s.do(http.MethodDelete, "/characters/"+c.ID, owner, nil).Code)
+}
+
+func (s *MetaAPITestSuite) TestWithoutIssuer() {
+	m, err := New("", data.NewOSRoot(s.T().TempDir()), nil, s.verifier)
+	s.Require().NoError(err)
+	s.Nil(m.api)
+}
+
+func TestMetaAPI(t *testing.T) {
+	suite.Run(t, new(MetaAPITestSuite))
+}
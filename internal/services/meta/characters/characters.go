@@ -0,0 +1,77 @@
+// Package characters stores the characters that belong to player accounts.
+package characters
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	// ErrExists is returned when creating a character whose name is taken.
+	ErrExists = errors.New("character name is taken")
+	// ErrNotFound is returned when no character matches.
+	ErrNotFound = errors.New("character not found")
+	// ErrInvalidName is returned for names that fail validation.
+	ErrInvalidName = errors.New("name must be 3-16 letters or digits and start with a letter")
+	// ErrLimitReached is returned when an account already has MaxPerAccount
+	// characters.
+	ErrLimitReached = errors.New("character limit reached")
+	// ErrInvalidItem is returned when giving an item with no ID or a count
+	// that is not positive.
+	ErrInvalidItem = errors.New("item needs an ID and a positive count")
+)
+
+// MaxPerAccount is the most characters a single account may own.
+const MaxPerAccount = 5
+
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]{2,15}$`)
+
+// Character is a persona an account plays as.
+type Character struct {
+	ID        string    `json:"id"`
+	AccountID string    `json:"account_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Inventory holds item counts keyed by item ID.
+	Inventory map[string]int `json:"inventory,omitempty"`
+}
+
+// clone returns a copy of c that shares no state with it.
+func (c *Character) clone() *Character {
+	cp := *c
+	if c.Inventory != nil {
+		cp.Inventory = make(map[string]int, len(c.Inventory))
+		for item, count := range c.Inventory {
+			cp.Inventory[item] = count
+		}
+	}
+	return &cp
+}
+
+// Store persists characters. Names are unique across all accounts, ignoring
+// case.
+type Store interface {
+	// Create adds a character named name to the account.
+	Create(accountID, name string) (*Character, error)
+
+	// Get retrieves a character by its ID.
+	Get(id string) (*Character, error)
+
+	// List returns the account's characters, oldest first.
+	List(accountID string) ([]*Character, error)
+
+	// Delete removes a character by its ID.
+	Delete(id string) error
+
+	// GiveItem adds count of an item to the character's inventory.
+	GiveItem(id, itemID string, count int) error
+}
+
+// ValidateName checks name against the character naming rules.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return ErrInvalidName
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package characters
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+)
+
+// FileStore persists characters as JSON files named by character ID. The
+// whole set is indexed in memory so name checks do not touch the disk.
+type FileStore struct {
+	root string
+
+	mu     sync.RWMutex
+	byID   map[string]*Character
+	byName map[string]string // lower case name -> ID
+}
+
+// NewFileStore opens the characters stored under root, creating it if needed.
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	s := &FileStore{
+		root:   root,
+		byID:   make(map[string]*Character),
+		byName: make(map[string]string),
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		var c Character
+		if err := data.ReadJSON(filepath.Join(root, e.Name()), &c); err != nil {
+			return nil, err
+		}
+		s.byID[c.ID] = &c
+		s.byName[strings.ToLower(c.Name)] = c.ID
+	}
+	return s, nil
+}
+
+func (s *FileStore) pathFor(id string) string {
+	return filepath.Join(s.root, id+".json")
+}
+
+func (s *FileStore) Create(accountID, name string) (*Character, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	id, err := auth.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(name)
+	if _, ok := s.byName[key]; ok {
+		return nil, ErrExists
+	}
+	if len(s.list(accountID)) >= MaxPerAccount {
+		return nil, ErrLimitReached
+	}
+	c := &Character{
+		ID:        id,
+		AccountID: accountID,
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := data.WriteJSON(s.pathFor(id), c); err != nil {
+		return nil, err
+	}
+	s.byID[id] = c
+	s.byName[key] = id
+	return c.clone(), nil
+}
+
+func (s *FileStore) Get(id string) (*Character, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.byID[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return c.clone(), nil
+}
+
+// Exists reports whether the character with id exists. The game checks it on
+// join, since a token outlives a deleted character.
+func (s *FileStore) Exists(id string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.byID[id]
+	return ok, nil
+}
+
+func (s *FileStore) List(accountID string) ([]*Character, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list(accountID), nil
+}
+
+func (s *FileStore) list(accountID string) []*Character {
+	out := []*Character{}
+	for _, c := range s.byID {
+		if c.AccountID == accountID {
+			out = append(out, c.clone())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.byID[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := os.Remove(s.pathFor(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	delete(s.byID, id)
+	delete(s.byName, strings.ToLower(c.Name))
+	return nil
+}
+
+// GiveItem adds count of an item to the character's inventory. The stored
+// copy only changes once the file has been written.
+func (s *FileStore) GiveItem(id, itemID string, count int) error {
+	if itemID == "" || count <= 0 {
+		return ErrInvalidItem
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.byID[id]
+	if !ok {
+		return ErrNotFound
+	}
+	updated := c.clone()
+	if updated.Inventory == nil {
+		updated.Inventory = make(map[string]int)
+	}
+	updated.Inventory[itemID] += count
+	if err := data.WriteJSON(s.pathFor(id), updated); err != nil {
+		return err
+	}
+	s.byID[id] = updated
+	return nil
+}
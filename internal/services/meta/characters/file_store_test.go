@@ -0,0 +1,110 @@
+package characters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileStoreSuite struct {
+	suite.Suite
+	dir   string
+	store *FileStore
+}
+
+func (s *FileStoreSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	store, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	s.store = store
+}
+
+func (s *FileStoreSuite) TestCreateListDelete() {
+	a, err := s.store.Create("account-a", "Aragorn")
+	s.Require().NoError(err)
+	_, err = s.store.Create("account-b", "Boromir")
+	s.Require().NoError(err)
+
+	list, err := s.store.List("account-a")
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+	s.Equal("Aragorn", list[0].Name)
+
+	exists, err := s.store.Exists(a.ID)
+	s.Require().NoError(err)
+	s.True(exists)
+
+	s.Require().NoError(s.store.Delete(a.ID))
+	_, err = s.store.Get(a.ID)
+	s.ErrorIs(err, ErrNotFound)
+	exists, err = s.store.Exists(a.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+	s.ErrorIs(s.store.Delete(a.ID), ErrNotFound)
+
+	// The name is free again.
+	_, err = s.store.Create("account-b", "aragorn")
+	s.NoError(err)
+}
+
+func (s *FileStoreSuite) TestNameUniqueAcrossAccounts() {
+	_, err := s.store.Create("account-a", "Gandalf")
+	s.Require().NoError(err)
+
+	_, err = s.store.Create("account-b", "GANDALF")
+	s.ErrorIs(err, ErrExists)
+}
+
+func (s *FileStoreSuite) TestValidation() {
+	for _, name := range []string{"", "Al", "1Hero", "Hero Man", "ThisNameIsTooLong", "../x"} {
+		_, err := s.store.Create("account-a", name)
+		s.ErrorIs(err, ErrInvalidName, name)
+	}
+}
+
+func (s *FileStoreSuite) TestLimit() {
+	for i := range MaxPerAccount {
+		_, err := s.store.Create("account-a", fmt.Sprintf("Hero%d", i))
+		s.Require().NoError(err)
+	}
+	_, err := s.store.Create("account-a", "OneTooMany")
+	s.ErrorIs(err, ErrLimitReached)
+}
+
+func (s *FileStoreSuite) TestPersists() {
+	c, err := s.store.Create("account-a", "Frodo")
+	s.Require().NoError(err)
+
+	reopened, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	got, err := reopened.Get(c.ID)
+	s.Require().NoError(err)
+	s.Equal(*c, *got)
+	_, err = reopened.Create("account-b", "frodo")
+	s.ErrorIs(err, ErrExists)
+}
+
+func (s *FileStoreSuite) TestGiveItem() {
+	c, err := s.store.Create("account-a", "Samwise")
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.store.GiveItem(c.ID, "rope", 1))
+	s.Require().NoError(s.store.GiveItem(c.ID, "rope", 2))
+	s.ErrorIs(s.store.GiveItem(c.ID, "", 1), ErrInvalidItem)
+	s.ErrorIs(s.store.GiveItem(c.ID, "rope", 0), ErrInvalidItem)
+	s.ErrorIs(s.store.GiveItem("missing", "rope", 1), ErrNotFound)
+
+	// Copies handed out earlier are not changed.
+	s.Nil(c.Inventory)
+
+	reopened, err := NewFileStore(s.dir)
+	s.Require().NoError(err)
+	got, err := reopened.Get(c.ID)
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"rope": 3}, got.Inventory)
+}
+
+func TestFileStore(t *testing.T) {
+	suite.Run(t, new(FileStoreSuite))
+}
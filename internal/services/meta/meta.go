@@ -4,70 +4,112 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"sync"
-	"time"
 
 	"github.com/go-chi/chi/v5"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+	"github.com/Odyssey-Classic/server/internal/services/meta/accounts"
+	"github.com/Odyssey-Classic/server/internal/services/meta/characters"
 )
 
 type Meta struct {
 	wg   *sync.WaitGroup
-	port uint16
+	addr string
 	once sync.Once
+	api  *API
+	srv  *http.Server
+
+	characters *characters.FileStore
+}
+
+// New creates the Meta service. Accounts and characters are stored under
+// root. Tokens are signed by issuer and checked by verifier; when issuer is
+// nil, tokens come from elsewhere and only the health check is served.
+func New(addr string, root data.Root, issuer *auth.Issuer, verifier auth.Verifier) (*Meta, error) {
+	m := &Meta{addr: addr}
+	if issuer == nil {
+		return m, nil
+	}
+	accountStore, err := accounts.NewFileStore(root.AccountsDir())
+	if err != nil {
+		return nil, err
+	}
+	characterStore, err := characters.NewFileStore(root.CharactersDir())
+	if err != nil {
+		return nil, err
+	}
+	m.characters = characterStore
+	m.api = newAPI(accountStore, characterStore, issuer, verifier)
+	return m, nil
 }
 
-func New(port uint16) *Meta {
-	return &Meta{port: port}
+// Characters returns the character store, or nil when tokens come from
+// elsewhere and this service keeps no characters.
+func (m *Meta) Characters() *characters.FileStore {
+	return m.characters
 }
 
+// Start binds the meta address and begins serving. It returns an error if the
+// address cannot be bound.
 func (m *Meta) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	var startErr error
 	m.once.Do(func() {
+		ln, err := net.Listen("tcp", m.addr)
+		if err != nil {
+			startErr = fmt.Errorf("meta: %w", err)
+			return
+		}
+		m.srv = &http.Server{
+			Handler:     m.routes(),
+			BaseContext: func(net.Listener) context.Context { return ctx },
+		}
+
 		m.wg = wg
 		m.wg.Add(1)
 		go func() {
-			startErr = m.start(ctx)
+			m.serve(ln)
 			m.wg.Done()
 		}()
 	})
 	return startErr
 }
 
-func (m *Meta) start(ctx context.Context) error {
+func (m *Meta) routes() http.Handler {
 	r := chi.NewRouter()
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("ok"))
 	})
-
-	srv := &http.Server{
-		Addr:    ":" + fmt.Sprintf("%d", m.port),
-		Handler: r,
+	if m.api != nil {
+		r.Mount("/", m.api)
+	} else {
+		slog.Info("meta token issuance disabled, serving health check only")
 	}
+	return r
+}
 
-	go func() {
-		<-ctx.Done()
-		slog.Info("meta shutting down")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err := srv.Shutdown(shutdownCtx); err != nil {
-			slog.Error("meta shutdown error", "err", err)
-		} else {
-			slog.Info("meta shutdown complete")
-		}
-	}()
-
-	slog.Info("meta API starting on :" + fmt.Sprintf("%d", m.port))
-	err := srv.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
+func (m *Meta) serve(ln net.Listener) {
+	slog.Info("meta API starting on " + ln.Addr().String())
+	if err := m.srv.Serve(ln); err != nil && err != http.ErrServerClosed {
 		slog.Error("meta API server error", "err", err)
 	}
-	return err
 }
 
-// Stop shuts down the Meta service
-func (m *Meta) Stop() {
-	// Implement shutdown logic here
+// Stop shuts down the Meta service, waiting for in-flight requests until ctx
+// ends.
+func (m *Meta) Stop(ctx context.Context) error {
+	if m.srv == nil {
+		return nil
+	}
+	slog.Info("meta shutting down")
+	if err := m.srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("meta: %w", err)
+	}
+	slog.Info("meta shutdown complete")
+	return nil
 }
@@ -0,0 +1,37 @@
+package meta
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/internal/data"
+)
+
+type MetaSuite struct {
+	suite.Suite
+}
+
+func (s *MetaSuite) TestStartReturnsBindError() {
+	taken, err := net.Listen("tcp", ":0")
+	s.Require().NoError(err)
+	defer taken.Close()
+
+	m, err := New(taken.Addr().String(), data.NewOSRoot(s.T().TempDir()), nil, auth.NewHMACVerifier([]byte("secret"), ""))
+	s.Require().NoError(err)
+	wg := &sync.WaitGroup{}
+	err = m.Start(context.Background(), wg)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "meta:")
+	s.NoError(m.Stop(context.Background()))
+	wg.Wait()
+}
+
+func TestMeta(t *testing.T) {
+	suite.Run(t, new(MetaSuite))
+}
@@ -2,63 +2,111 @@ package network
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/Odyssey-Classic/server/internal/auth"
 	"github.com/Odyssey-Classic/server/pb"
 	"github.com/gorilla/websocket"
 	"google.golang.org/protobuf/proto"
 )
 
+// writeWait is the time allowed to write a single message to the remote.
+const writeWait = 10 * time.Second
+
 // Represents a client with a WebSocket connection
 type Client struct {
-	conn       *websocket.Conn
-	fromRemote chan any
-	toRemote   chan any
+	// id uniquely identifies the connection for the life of the server.
+	id       uint64
+	conn     *websocket.Conn
+	toRemote chan *pb.GameMessage
+
+	// Identity taken from the verified bearer token.
+	accountID     string
+	characterID   string
+	characterName string
 
-	closed bool
+	closeOnce sync.Once
+	// closed is closed by close, ending the read and write loops.
+	closed chan struct{}
 }
 
-func NewClient(conn *websocket.Conn) *Client {
+func NewClient(id uint64, conn *websocket.Conn, claims *auth.Claims) *Client {
 	return &Client{
-		conn:       conn,
-		fromRemote: make(chan any, 10),
-		toRemote:   make(chan any, 10),
+		id:            id,
+		conn:          conn,
+		toRemote:      make(chan *pb.GameMessage, 10),
+		closed:        make(chan struct{}),
+		accountID:     claims.AccountID(),
+		characterID:   claims.CharacterID,
+		characterName: claims.CharacterName,
 	}
 }
 
-func (c *Client) close() error {
-	return c.conn.Close()
+// ID returns the client's connection ID.
+func (c *Client) ID() uint64 {
+	return c.id
+}
+
+// AccountID returns the account the client authenticated as.
+func (c *Client) AccountID() string {
+	return c.accountID
+}
+
+// CharacterID returns the character the client's token was issued for, if any.
+func (c *Client) CharacterID() string {
+	return c.characterID
+}
+
+// CharacterName returns the display name of the character, if the token
+// carries one.
+func (c *Client) CharacterName() string {
+	return c.characterName
+}
+
+// close sends a close frame with the given code and reason, then closes the
+// underlying connection. Only the first call has any effect.
+func (c *Client) close(code int, reason string) error {
+	var err error
+	c.closeOnce.Do(func() {
+		// Best effort: the remote may already be gone.
+		deadline := time.Now().Add(writeWait)
+		_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+		err = c.conn.Close()
+		close(c.closed)
+	})
+	return err
 }
 
 // Reads a single message
-func (c *Client) read() (any, error) {
+func (c *Client) read() (*pb.GameMessage, error) {
 	_, bytes, err := c.conn.ReadMessage()
 	if err != nil {
-		slog.Error(err.Error())
 		return nil, err
 	}
 
 	msg := &pb.GameMessage{}
-	err = proto.Unmarshal(bytes, msg)
-	if err != nil {
-		slog.Error(err.Error())
+	if err := proto.Unmarshal(bytes, msg); err != nil {
+		return nil, fmt.Errorf("unmarshaling message: %w", err)
 	}
 
-	slog.Info("received message", "type", msg.Type)
+	slog.Debug("received message", "payload", fmt.Sprintf("%T", msg.Payload))
+	return msg, nil
+}
 
-	bytes, err = proto.Marshal(msg)
+// Writes a single message
+func (c *Client) write(msg *pb.GameMessage) error {
+	bytes, err := proto.Marshal(msg)
 	if err != nil {
-		slog.Error("marshaling msg", "error", err)
+		return fmt.Errorf("marshaling message: %w", err)
 	}
-	c.conn.WriteMessage(websocket.BinaryMessage, bytes)
-
-	return msg, err
-}
 
-// Writes a single message
-func (c *Client) write(msg any) error {
-	slog.Debug("writing message", "message", msg)
-	return nil
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.BinaryMessage, bytes)
 }
 
 // Infinite loop that sends messages to remote
@@ -66,43 +114,52 @@ func (c *Client) processOutbound(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
-			c.close()
+			c.close(websocket.CloseGoingAway, "")
+			return nil
+		case <-c.closed:
 			return nil
 		case msg := <-c.toRemote:
 			err := c.write(msg)
 			if err != nil {
 				slog.ErrorContext(ctx, "writing", "error", err)
-				c.close()
+				c.close(websocket.CloseInternalServerErr, "write failed")
 				return err
 			}
+			// A Disconnect is always the last message on a connection.
+			if d := msg.GetDisconnect(); d != nil {
+				c.close(websocket.CloseNormalClosure, d.GetMessage())
+				return nil
+			}
 		}
 	}
 }
 
-// Infinite loop that receives messages from remote
-func (c *Client) processInbound(ctx context.Context) error {
-	slog.DebugContext(ctx, "pre for")
+// Infinite loop that receives messages from remote and forwards them to events.
+// Once stopping is closed nobody may be reading events, so messages still
+// arriving are dropped.
+func (c *Client) processInbound(ctx context.Context, events chan<- Event, stopping <-chan struct{}) error {
 	for {
-		slog.DebugContext(ctx, "pre select")
 		select {
 		case <-ctx.Done():
-			c.close()
+			c.close(websocket.CloseGoingAway, "")
 			return nil
 		default:
 			msg, err := c.read()
 			if err != nil {
 				slog.ErrorContext(ctx, "reading", "error", err)
-				c.close()
+				c.close(websocket.CloseNormalClosure, "")
 				return err
 			}
 
 			select {
-			case c.fromRemote <- msg:
-				// Message successfully pushed to channel.
-			default:
-				// Message failed push to channel.
-				// Messages coming faster than we can process them?
-				// TODO figure out if we need to worry about this.
+			case events <- ClientMessage{Client: c, Message: msg}:
+			case <-ctx.Done():
+				c.close(websocket.CloseGoingAway, "")
+				return nil
+			case <-stopping:
+				return nil
+			case <-c.closed:
+				return nil
 			}
 		}
 	}
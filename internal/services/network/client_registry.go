@@ -0,0 +1,89 @@
+package network
+
+import "sync"
+
+// clientRegistry tracks connected clients. It is safe for concurrent use.
+type clientRegistry struct {
+	mu        sync.RWMutex
+	byID      map[uint64]*Client
+	byAccount map[string]map[uint64]*Client
+}
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{
+		byID:      make(map[uint64]*Client),
+		byAccount: make(map[string]map[uint64]*Client),
+	}
+}
+
+// add registers c, replacing any client with the same connection ID.
+func (r *clientRegistry) add(c *Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byID[c.id] = c
+	clients, ok := r.byAccount[c.accountID]
+	if !ok {
+		clients = make(map[uint64]*Client)
+		r.byAccount[c.accountID] = clients
+	}
+	clients[c.id] = c
+}
+
+// remove unregisters c. Removing a client that is not registered is a no-op.
+func (r *clientRegistry) remove(c *Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.byID, c.id)
+	if clients, ok := r.byAccount[c.accountID]; ok {
+		delete(clients, c.id)
+		if len(clients) == 0 {
+			delete(r.byAccount, c.accountID)
+		}
+	}
+}
+
+// get returns the client with the given connection ID.
+func (r *clientRegistry) get(id uint64) (*Client, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.byID[id]
+	return c, ok
+}
+
+// forAccount returns every client connected with the given account ID.
+func (r *clientRegistry) forAccount(accountID string) []*Client {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*Client, 0, len(r.byAccount[accountID]))
+	for _, c := range r.byAccount[accountID] {
+		out = append(out, c)
+	}
+	return out
+}
+
+// count returns the number of registered clients.
+func (r *clientRegistry) count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.byID)
+}
+
+// each calls fn for every registered client. fn is called on a snapshot taken
+// without the lock held, so it may safely add or remove clients.
+func (r *clientRegistry) each(fn func(*Client)) {
+	r.mu.RLock()
+	clients := make([]*Client, 0, len(r.byID))
+	for _, c := range r.byID {
+		clients = append(clients, c)
+	}
+	r.mu.RUnlock()
+
+	for _, c := range clients {
+		fn(c)
+	}
+}
@@ -0,0 +1,84 @@
+package network
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientRegistrySuite struct {
+	suite.Suite
+	reg *clientRegistry
+}
+
+func (s *ClientRegistrySuite) SetupTest() {
+	s.reg = newClientRegistry()
+}
+
+func (s *ClientRegistrySuite) TestAddGetRemove() {
+	c := &Client{id: 1, accountID: "a"}
+	s.reg.add(c)
+
+	got, ok := s.reg.get(1)
+	s.True(ok)
+	s.Same(c, got)
+	s.Equal(1, s.reg.count())
+
+	s.reg.remove(c)
+	_, ok = s.reg.get(1)
+	s.False(ok)
+	s.Zero(s.reg.count())
+	s.Empty(s.reg.forAccount("a"))
+
+	// Removing twice is harmless.
+	s.reg.remove(c)
+}
+
+func (s *ClientRegistrySuite) TestForAccount() {
+	s.reg.add(&Client{id: 1, accountID: "a"})
+	s.reg.add(&Client{id: 2, accountID: "a"})
+	s.reg.add(&Client{id: 3, accountID: "b"})
+
+	s.Len(s.reg.forAccount("a"), 2)
+	s.Len(s.reg.forAccount("b"), 1)
+	s.Empty(s.reg.forAccount("c"))
+}
+
+func (s *ClientRegistrySuite) TestEachAllowsRemoval() {
+	for i := uint64(1); i <= 3; i++ {
+		s.reg.add(&Client{id: i})
+	}
+
+	seen := 0
+	s.reg.each(func(c *Client) {
+		seen++
+		s.reg.remove(c)
+	})
+	s.Equal(3, seen)
+	s.Zero(s.reg.count())
+}
+
+func (s *ClientRegistrySuite) TestConcurrentAccess() {
+	var wg sync.WaitGroup
+	for i := uint64(1); i <= 100; i++ {
+		wg.Add(1)
+		go func(id uint64) {
+			defer wg.Done()
+			c := &Client{id: id, accountID: "a"}
+			s.reg.add(c)
+			s.reg.get(id)
+			s.reg.forAccount("a")
+			s.reg.each(func(*Client) {})
+			if id%2 == 0 {
+				s.reg.remove(c)
+			}
+		}(i)
+	}
+	wg.Wait()
+	s.Equal(50, s.reg.count())
+}
+
+func TestClientRegistry(t *testing.T) {
+	suite.Run(t, new(ClientRegistrySuite))
+}
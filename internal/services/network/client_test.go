@@ -0,0 +1,128 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+type ClientSuite struct {
+	suite.Suite
+	srv    *httptest.Server
+	client chan *Client
+	remote *websocket.Conn
+}
+
+func (s *ClientSuite) SetupTest() {
+	s.client = make(chan *Client, 1)
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		s.Require().NoError(err)
+		s.client <- NewClient(1, conn, &auth.Claims{})
+	}))
+
+	url := "ws" + strings.TrimPrefix(s.srv.URL, "http")
+	remote, _, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Require().NoError(err)
+	s.remote = remote
+}
+
+func (s *ClientSuite) TearDownTest() {
+	s.remote.Close()
+	s.srv.Close()
+}
+
+func (s *ClientSuite) TestOutboundIsWrittenAsBinaryProto() {
+	c := <-s.client
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.processOutbound(ctx)
+
+	c.toRemote <- &pb.GameMessage{
+		Version: pb.ProtocolVersion_PROTOCOL_VERSION_1,
+		Payload: &pb.GameMessage_Chat{Chat: &pb.Chat{Text: "hello"}},
+	}
+
+	s.remote.SetReadDeadline(time.Now().Add(time.Second))
+	kind, data, err := s.remote.ReadMessage()
+	s.Require().NoError(err)
+	s.Equal(websocket.BinaryMessage, kind)
+
+	var got pb.GameMessage
+	s.Require().NoError(proto.Unmarshal(data, &got))
+	s.Equal("hello", got.GetChat().GetText())
+}
+
+func (s *ClientSuite) TestDisconnectClosesAfterWrite() {
+	c := <-s.client
+	done := make(chan error)
+	go func() { done <- c.processOutbound(context.Background()) }()
+
+	c.toRemote <- &pb.GameMessage{
+		Payload: &pb.GameMessage_Disconnect{Disconnect: &pb.Disconnect{Reason: pb.DisconnectReason_DISCONNECT_REASON_KICKED}},
+	}
+
+	s.remote.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err := s.remote.ReadMessage()
+	s.Require().NoError(err)
+	var got pb.GameMessage
+	s.Require().NoError(proto.Unmarshal(data, &got))
+	s.NotNil(got.GetDisconnect())
+
+	_, _, err = s.remote.ReadMessage()
+	s.True(websocket.IsCloseError(err, websocket.CloseNormalClosure))
+	s.NoError(<-done)
+}
+
+func (s *ClientSuite) TestInboundIsNotEchoed() {
+	c := <-s.client
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan Event, 1)
+	go c.processInbound(ctx, events, nil)
+
+	data, err := proto.Marshal(&pb.GameMessage{
+		Payload: &pb.GameMessage_JoinGame{JoinGame: &pb.JoinGame{CharacterId: "abc"}},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.remote.WriteMessage(websocket.BinaryMessage, data))
+
+	select {
+	case ev := <-events:
+		msg := ev.(ClientMessage)
+		s.Same(c, msg.Client)
+		s.Equal("abc", msg.Message.GetJoinGame().GetCharacterId())
+	case <-time.After(time.Second):
+		s.Fail("message was not delivered")
+	}
+
+	s.remote.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err = s.remote.ReadMessage()
+	s.Error(err, "client should not echo inbound messages")
+}
+
+func (s *ClientSuite) TestCloseSendsCloseFrame() {
+	c := <-s.client
+	s.Require().NoError(c.close(websocket.CloseGoingAway, "bye"))
+
+	s.remote.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := s.remote.ReadMessage()
+	s.True(websocket.IsCloseError(err, websocket.CloseGoingAway))
+
+	// Subsequent closes are no-ops.
+	s.NoError(c.close(websocket.CloseNormalClosure, ""))
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
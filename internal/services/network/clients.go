@@ -7,17 +7,23 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// processClient runs the client's read and write loops until it disconnects.
+// The caller must already have added the client to clientGroup.
 func (n *Network) processClient(ctx context.Context, client *Client) {
-	eg, ctx := errgroup.WithContext(ctx)
+	eg, clientCtx := errgroup.WithContext(ctx)
 
-	n.clientGroup.Add(1)
 	go func() {
 		// errgroup.Go does not have a way to use its own context
-		eg.Go(func() error { return client.processInbound(ctx) })
-		eg.Go(func() error { return client.processOutbound(ctx) })
+		eg.Go(func() error { return client.processInbound(clientCtx, n.Out, n.stopping) })
+		eg.Go(func() error { return client.processOutbound(clientCtx) })
 
 		err := eg.Wait()
-		slog.Error("client process failed", "error", err)
+		n.clients.remove(client)
+		if err != nil {
+			slog.Error("client process failed", "id", client.id, "error", err)
+		}
+		slog.Info("client removed", "id", client.id, "account", client.accountID)
+		n.emit(ctx, ClientDisconnected{Client: client, Err: err})
 		n.clientGroup.Done()
 	}()
 }
@@ -0,0 +1,33 @@
+package network
+
+import "github.com/Odyssey-Classic/server/pb"
+
+// Event is something the Network service reports to the game. It is one of
+// ClientConnected, ClientDisconnected or ClientMessage.
+type Event interface {
+	event()
+}
+
+// ClientConnected is sent once a client has authenticated and upgraded to a
+// websocket.
+type ClientConnected struct {
+	Client *Client
+}
+
+// ClientDisconnected is sent once a client's connection has closed and it has
+// been removed from the network. Err is the reason the connection ended, or
+// nil for a clean shutdown.
+type ClientDisconnected struct {
+	Client *Client
+	Err    error
+}
+
+// ClientMessage is a decoded message received from a client.
+type ClientMessage struct {
+	Client  *Client
+	Message *pb.GameMessage
+}
+
+func (ClientConnected) event()    {}
+func (ClientDisconnected) event() {}
+func (ClientMessage) event()      {}
@@ -25,7 +25,6 @@ func (n *Network) wsConnect(ctx context.Context) http.HandlerFunc {
 		}
 
 		// Extract client metadata from JWT token
-		// Use unvalidated JWT tokens for simplicity
 		token := r.Header.Get("Authorization")
 		if token == "" {
 			http.Error(w, "Authorization token required", http.StatusUnauthorized)
@@ -39,10 +38,14 @@ func (n *Network) wsConnect(ctx context.Context) http.HandlerFunc {
 			slog.Warn("invalid authorization header format", "remote_addr", r.RemoteAddr)
 			return
 		}
-		// jwtToken := token[len(bearerPrefix):]
+		jwtToken := token[len(bearerPrefix):]
 
-		// TODO: Validate JWT token here
-		// If invalid, return http.Error and do not upgrade
+		claims, err := n.verifier.Verify(jwtToken)
+		if err != nil {
+			http.Error(w, "Invalid authorization token", http.StatusUnauthorized)
+			slog.Warn("invalid authorization token", "remote_addr", r.RemoteAddr, "error", err)
+			return
+		}
 
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
@@ -50,13 +53,9 @@ func (n *Network) wsConnect(ctx context.Context) http.HandlerFunc {
 			return
 		}
 
-		client := &Client{
-			conn: conn,
-		}
+		client := NewClient(n.nextClientID.Add(1), conn, claims)
 
 		n.addClient(ctx, client)
-		// Create new Client with conn `c`
-		// Add client to clients map
 
 		// Send client connection to Game Logic
 		// Game Logic makes a "player" object with the client
@@ -0,0 +1,212 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+type HandlerSuite struct {
+	suite.Suite
+	secret  []byte
+	network *Network
+}
+
+func (s *HandlerSuite) SetupTest() {
+	s.secret = []byte("test-secret")
+	s.network = New("", auth.NewHMACVerifier(s.secret, "odyssey"))
+}
+
+func (s *HandlerSuite) request(authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	s.network.wsConnect(context.Background()).ServeHTTP(w, req)
+	return w
+}
+
+func (s *HandlerSuite) token(key []byte, expires time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "account-1",
+			Audience:  jwt.ClaimStrings{"odyssey"},
+			ExpiresAt: jwt.NewNumericDate(expires),
+		},
+	}).SignedString(key)
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *HandlerSuite) TestMissingToken() {
+	s.Equal(http.StatusUnauthorized, s.request("").Code)
+}
+
+func (s *HandlerSuite) TestMalformedHeader() {
+	s.Equal(http.StatusUnauthorized, s.request("Token abc").Code)
+}
+
+func (s *HandlerSuite) TestBadSignature() {
+	token := s.token([]byte("other"), time.Now().Add(time.Hour))
+	s.Equal(http.StatusUnauthorized, s.request("Bearer "+token).Code)
+}
+
+func (s *HandlerSuite) TestExpiredToken() {
+	token := s.token(s.secret, time.Now().Add(-time.Hour))
+	s.Equal(http.StatusUnauthorized, s.request("Bearer "+token).Code)
+}
+
+func (s *HandlerSuite) TestValidTokenRegistersClient() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := httptest.NewServer(s.network.wsConnect(ctx))
+	defer srv.Close()
+	go s.network.dispatch(ctx)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.token(s.secret, time.Now().Add(time.Hour)))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+	s.Require().NoError(err)
+
+	connected := s.nextEvent().(ClientConnected)
+	s.Equal("account-1", connected.Client.AccountID())
+	s.Equal(1, s.network.clients.count())
+	s.Len(s.network.clients.forAccount("account-1"), 1)
+
+	// Inbound messages arrive as typed events.
+	data, err := proto.Marshal(&pb.GameMessage{Payload: &pb.GameMessage_Chat{Chat: &pb.Chat{Text: "hi"}}})
+	s.Require().NoError(err)
+	s.Require().NoError(conn.WriteMessage(websocket.BinaryMessage, data))
+	msg := s.nextEvent().(ClientMessage)
+	s.Same(connected.Client, msg.Client)
+	s.Equal("hi", msg.Message.GetChat().GetText())
+
+	// Outbound messages reach the addressed client.
+	s.network.In <- ToClient(connected.Client.ID(), &pb.GameMessage{Payload: &pb.GameMessage_Chat{Chat: &pb.Chat{Text: "hello"}}})
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err = conn.ReadMessage()
+	s.Require().NoError(err)
+	var reply pb.GameMessage
+	s.Require().NoError(proto.Unmarshal(data, &reply))
+	s.Equal("hello", reply.GetChat().GetText())
+
+	// Client is removed once its connection goes away.
+	conn.Close()
+	disconnected := s.nextEvent().(ClientDisconnected)
+	s.Same(connected.Client, disconnected.Client)
+	s.Zero(s.network.clients.count())
+}
+
+func (s *HandlerSuite) TestStopDisconnectsClients() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := httptest.NewServer(s.network.wsConnect(ctx))
+	defer srv.Close()
+	go s.network.dispatch(ctx)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.token(s.secret, time.Now().Add(time.Hour)))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+	s.Require().NoError(err)
+	defer conn.Close()
+	s.nextEvent()
+
+	s.Require().NoError(s.network.Stop(context.Background()))
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err := conn.ReadMessage()
+	s.Require().NoError(err)
+	var bye pb.GameMessage
+	s.Require().NoError(proto.Unmarshal(data, &bye))
+	s.Equal(pb.DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN, bye.GetDisconnect().GetReason())
+	_, _, err = conn.ReadMessage()
+	s.True(websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error %v", err)
+	s.Zero(s.network.clients.count())
+}
+
+func (s *HandlerSuite) TestStopWithNobodyReadingEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := httptest.NewServer(s.network.wsConnect(ctx))
+	defer srv.Close()
+	go s.network.dispatch(ctx)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.token(s.secret, time.Now().Add(time.Hour)))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	// The game has stopped, so nothing drains Out and the client's reads back up.
+	data, err := proto.Marshal(&pb.GameMessage{Payload: &pb.GameMessage_Chat{Chat: &pb.Chat{Text: "hi"}}})
+	s.Require().NoError(err)
+	for range 300 {
+		s.Require().NoError(conn.WriteMessage(websocket.BinaryMessage, data))
+	}
+	s.Eventually(func() bool { return len(s.network.Out) == cap(s.network.Out) }, time.Second, 10*time.Millisecond)
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer stopCancel()
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.network.Stop(stopCtx) }()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		s.FailNow("Stop did not return")
+	}
+	s.Zero(s.network.clients.count())
+}
+
+func (s *HandlerSuite) TestClientAfterStopIsRefused() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := httptest.NewServer(s.network.wsConnect(ctx))
+	defer srv.Close()
+	s.Require().NoError(s.network.Stop(context.Background()))
+
+	// The test server outlives Stop, as a hijacked handler outlives Shutdown.
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.token(s.secret, time.Now().Add(time.Hour)))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err := conn.ReadMessage()
+	s.Require().NoError(err)
+	var bye pb.GameMessage
+	s.Require().NoError(proto.Unmarshal(data, &bye))
+	s.Equal(pb.DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN, bye.GetDisconnect().GetReason())
+	_, _, err = conn.ReadMessage()
+	s.True(websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error %v", err)
+	s.Zero(s.network.clients.count())
+	s.Empty(s.network.Out)
+}
+
+func (s *HandlerSuite) nextEvent() Event {
+	select {
+	case ev := <-s.network.Out:
+		return ev
+	case <-time.After(time.Second):
+		s.FailNow("no event received")
+		return nil
+	}
+}
+
+func TestHandler(t *testing.T) {
+	suite.Run(t, new(HandlerSuite))
+}
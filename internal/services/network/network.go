@@ -2,98 +2,262 @@ package network
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/pb"
 )
 
-type ClientMap map[*websocket.Conn]*Client
+// eventBuffer is the capacity of the channels shared with the game.
+const eventBuffer = 256
+
+// closeWait bounds how long Stop waits for client goroutines to end after it
+// has closed their connections.
+const closeWait = time.Second
 
 type Network struct {
 	wg   *sync.WaitGroup
-	port uint16
+	addr string
 	once sync.Once
 
 	clientGroup *sync.WaitGroup
+	verifier    auth.Verifier
+
+	// Out carries events from connected clients to the game.
+	Out chan Event
+	// In carries messages from the game to connected clients.
+	In chan Outbound
+
+	clients *clientRegistry
+	// nextClientID is the last connection ID handed out.
+	nextClientID atomic.Uint64
 
-	Out     chan any
-	clients ClientMap
+	server *http.Server
+	// stopping is closed when Stop begins; dispatched is closed once every
+	// message the game sent before then has been handed to its client.
+	stopping      chan struct{}
+	dispatched    chan struct{}
+	stopOnce      sync.Once
+	stopAccepting sync.Once
+	// admitMu orders admitting a client against stopping. Hijacked
+	// connections outlive http.Server.Shutdown, so without it a late client
+	// could be added to clientGroup while Stop waits on it.
+	admitMu sync.Mutex
 }
 
-func New(port uint16) *Network {
+// New creates a Network service listening on addr, such as ":8080".
+// Connections must present a bearer token accepted by verifier.
+func New(addr string, verifier auth.Verifier) *Network {
 	return &Network{
-		port:        port,
+		addr:        addr,
 		clientGroup: new(sync.WaitGroup),
+		verifier:    verifier,
+		clients:     newClientRegistry(),
+		stopping:    make(chan struct{}),
+		dispatched:  make(chan struct{}),
 
-		Out: make(chan any, 10),
+		Out: make(chan Event, eventBuffer),
+		In:  make(chan Outbound, eventBuffer),
 	}
 }
 
+// Start binds the listener and begins accepting connections. It returns an
+// error if the address cannot be bound.
 func (n *Network) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	var startErr error
 	n.once.Do(func() {
+		ln, err := net.Listen("tcp", n.addr)
+		if err != nil {
+			startErr = fmt.Errorf("network: %w", err)
+			return
+		}
+		n.server = &http.Server{
+			Handler:     n.wsConnect(ctx),
+			BaseContext: func(net.Listener) context.Context { return ctx },
+		}
+
 		n.wg = wg
-		n.wg.Add(1)
+		n.wg.Add(2)
+		go func() {
+			n.dispatch(ctx)
+			n.wg.Done()
+		}()
 		go func() {
-			startErr = n.start(ctx)
+			n.serve(ln)
 			n.wg.Done()
 		}()
 	})
 	return startErr
 }
 
-func (n *Network) start(ctx context.Context) error {
-	server := &http.Server{
-		Addr: fmt.Sprintf(":%d", n.port),
+func (n *Network) serve(ln net.Listener) {
+	slog.Info("network API starting on " + ln.Addr().String())
+	if err := n.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+		slog.Error("network server error", "err", err)
 	}
-	server.Handler = n.wsConnect(ctx)
-	server.BaseContext = func(listener net.Listener) context.Context { return ctx }
+}
 
-	go func() {
-		<-ctx.Done()
-		slog.Info("network shutting down")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		n.shutdown(shutdownCtx)
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			slog.Error("network shutdown error", "err", err)
-		} else {
-			slog.Info("network shutdown complete")
+func (n *Network) addClient(ctx context.Context, client *Client) {
+	n.admitMu.Lock()
+	select {
+	case <-n.stopping:
+		n.admitMu.Unlock()
+		slog.Info("refusing client, shutting down", "id", client.id, "account", client.accountID)
+		// Best effort: the client is closed either way.
+		_ = client.write(shutdownMessage())
+		client.close(websocket.CloseGoingAway, "server shutting down")
+		return
+	default:
+	}
+	slog.Info("adding client", "id", client.id, "account", client.accountID, "remote addr", client.conn.RemoteAddr())
+	// Counted before it is visible so that Stop waits for it.
+	n.clientGroup.Add(1)
+	n.clients.add(client)
+	n.admitMu.Unlock()
+
+	n.emit(ctx, ClientConnected{Client: client})
+	n.processClient(ctx, client)
+}
+
+// emit passes ev to the game, giving up if ctx ends or the network stops
+// first.
+func (n *Network) emit(ctx context.Context, ev Event) {
+	select {
+	case n.Out <- ev:
+	case <-ctx.Done():
+	case <-n.stopping:
+	}
+}
+
+// dispatch delivers messages from the game to their recipients until ctx ends
+// or the network stops. When stopping, whatever the game has already sent is
+// still delivered.
+func (n *Network) dispatch(ctx context.Context) {
+	defer close(n.dispatched)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case out := <-n.In:
+			n.deliver(out)
+		case <-n.stopping:
+			for {
+				select {
+				case out := <-n.In:
+					n.deliver(out)
+				default:
+					return
+				}
+			}
 		}
-	}()
+	}
+}
 
-	slog.Info("network API starting on :" + fmt.Sprintf("%d", n.port))
-	err := server.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
-		slog.Error("network server error", "err", err)
+func (n *Network) deliver(out Outbound) {
+	if out.Broadcast {
+		n.clients.each(func(c *Client) { n.enqueue(c, out) })
+		return
+	}
+	for _, id := range out.To {
+		if c, ok := n.clients.get(id); ok {
+			n.enqueue(c, out)
+		}
 	}
-	return err
 }
 
-func (n *Network) addClient(ctx context.Context, client *Client) {
-	slog.Info("adding client", "remote addr", client.conn.RemoteAddr())
-	n.clients[client.conn] = client
-	n.Out <- client
-	n.processClient(ctx, client)
+// enqueue hands the message to the client's writer without blocking. A client
+// that cannot keep up is disconnected rather than allowed to stall everyone
+// else.
+func (n *Network) enqueue(c *Client, out Outbound) {
+	select {
+	case c.toRemote <- out.Message:
+	default:
+		slog.Warn("client send buffer full, disconnecting", "id", c.id)
+		c.close(websocket.ClosePolicyViolation, "too slow")
+	}
 }
 
-func (n *Network) shutdown(_ context.Context) {
-	slog.Info("shutting down clients")
-	for _, client := range n.clients {
-		err := client.close()
-		if err != nil {
-			slog.Error("error closing client", "error", err)
+// ClientCount returns the number of connected clients. It is safe to call
+// from any goroutine.
+func (n *Network) ClientCount() int {
+	return n.clients.count()
+}
+
+// StopAccepting closes the listener so no new clients can connect. Connected
+// clients are unaffected.
+func (n *Network) StopAccepting(ctx context.Context) error {
+	var err error
+	n.stopAccepting.Do(func() {
+		if n.server != nil {
+			err = n.server.Shutdown(ctx)
+		}
+	})
+	return err
+}
+
+// Stop closes the listener, delivers every message the game has already sent
+// and tells the remaining clients the server is going down. Clients that have
+// not disconnected by the time ctx ends are closed forcibly.
+func (n *Network) Stop(ctx context.Context) error {
+	err := n.StopAccepting(ctx)
+	n.admitMu.Lock()
+	n.stopOnce.Do(func() { close(n.stopping) })
+	n.admitMu.Unlock()
+	if n.server != nil {
+		select {
+		case <-n.dispatched:
+		case <-ctx.Done():
 		}
 	}
-	slog.Info("clients shutdown")
+
+	bye := shutdownMessage()
+	n.clients.each(func(c *Client) { n.enqueue(c, Outbound{Message: bye}) })
+
+	if waitErr := wait(ctx, n.clientGroup); waitErr != nil {
+		slog.Warn("clients did not disconnect in time, closing", "clients", n.clients.count())
+		n.clients.each(func(c *Client) { c.close(websocket.CloseGoingAway, "server shutting down") })
+		closeCtx, cancel := context.WithTimeout(context.Background(), closeWait)
+		defer cancel()
+		if closeErr := wait(closeCtx, n.clientGroup); closeErr != nil {
+			slog.Error("client goroutines did not end after closing", "clients", n.clients.count())
+		}
+		err = errors.Join(err, waitErr)
+	}
+	slog.Info("network shutdown complete")
+	return err
 }
 
-// Stop shuts down the Network service
-func (n *Network) Stop() {
-	// Implement shutdown logic here
+// shutdownMessage tells a client the server is going down.
+func shutdownMessage() *pb.GameMessage {
+	return &pb.GameMessage{
+		Version: pb.ProtocolVersion_PROTOCOL_VERSION_1,
+		Payload: &pb.GameMessage_Disconnect{Disconnect: &pb.Disconnect{
+			Reason:  pb.DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN,
+			Message: "server shutting down",
+		}},
+	}
+}
+
+// wait waits for wg, giving up when ctx ends.
+func wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -0,0 +1,72 @@
+package network
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/Odyssey-Classic/server/internal/auth"
+	"github.com/Odyssey-Classic/server/pb"
+)
+
+type NetworkSuite struct {
+	suite.Suite
+	network *Network
+	clients []*Client
+}
+
+func (s *NetworkSuite) SetupTest() {
+	s.network = New("", auth.NewHMACVerifier([]byte("secret"), ""))
+	s.clients = nil
+	for id := uint64(1); id <= 3; id++ {
+		c := &Client{id: id, toRemote: make(chan *pb.GameMessage, 1)}
+		s.network.clients.add(c)
+		s.clients = append(s.clients, c)
+	}
+}
+
+// received returns how many messages each client has queued.
+func (s *NetworkSuite) received() []int {
+	out := make([]int, len(s.clients))
+	for i, c := range s.clients {
+		out[i] = len(c.toRemote)
+	}
+	return out
+}
+
+func (s *NetworkSuite) TestDeliverToClient() {
+	s.network.deliver(ToClient(2, &pb.GameMessage{}))
+	s.Equal([]int{0, 1, 0}, s.received())
+}
+
+func (s *NetworkSuite) TestDeliverToClients() {
+	s.network.deliver(ToClients([]uint64{1, 3, 99}, &pb.GameMessage{}))
+	s.Equal([]int{1, 0, 1}, s.received())
+}
+
+func (s *NetworkSuite) TestDeliverToAll() {
+	s.network.deliver(ToAll(&pb.GameMessage{}))
+	s.Equal([]int{1, 1, 1}, s.received())
+}
+
+func (s *NetworkSuite) TestStartReturnsBindError() {
+	taken, err := net.Listen("tcp", ":0")
+	s.Require().NoError(err)
+	defer taken.Close()
+
+	n := New(taken.Addr().String(), auth.NewHMACVerifier([]byte("secret"), ""))
+	wg := &sync.WaitGroup{}
+	err = n.Start(context.Background(), wg)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "network:")
+	s.NoError(n.Stop(context.Background()))
+	wg.Wait()
+}
+
+func TestNetwork(t *testing.T) {
+	suite.Run(t, new(NetworkSuite))
+}
@@ -0,0 +1,28 @@
+package network
+
+import "github.com/Odyssey-Classic/server/pb"
+
+// Outbound is a message the game wants delivered to clients.
+// Build one with ToClient, ToClients or ToAll.
+type Outbound struct {
+	// To lists the connection IDs of the recipients. Ignored when Broadcast
+	// is set.
+	To        []uint64
+	Broadcast bool
+	Message   *pb.GameMessage
+}
+
+// ToClient addresses msg to a single client.
+func ToClient(id uint64, msg *pb.GameMessage) Outbound {
+	return Outbound{To: []uint64{id}, Message: msg}
+}
+
+// ToClients addresses msg to each of the given clients.
+func ToClients(ids []uint64, msg *pb.GameMessage) Outbound {
+	return Outbound{To: ids, Message: msg}
+}
+
+// ToAll addresses msg to every connected client.
+func ToAll(msg *pb.GameMessage) Outbound {
+	return Outbound{Broadcast: true, Message: msg}
+}
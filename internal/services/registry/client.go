@@ -0,0 +1,283 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultHeartbeatInterval is how often a registered server reports in.
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// errUnknownServer is returned when the registry no longer knows our ID.
+var errUnknownServer = errors.New("registry does not know this server")
+
+// Info describes the server to the registry.
+type Info struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Ports    Ports  `json:"ports"`
+	Capacity int    `json:"capacity"`
+}
+
+// Ports are the public ports players connect to.
+type Ports struct {
+	Meta    uint16 `json:"meta"`
+	Network uint16 `json:"network"`
+}
+
+// Heartbeat is sent periodically while the server is registered.
+type Heartbeat struct {
+	Players  int `json:"players"`
+	Capacity int `json:"capacity"`
+}
+
+// Registration is the registry's reply to a successful registration.
+type Registration struct {
+	ID string `json:"id"`
+}
+
+// Backoff controls retries of failed registry requests. Each retry waits
+// twice as long as the last, starting at Initial and capped at Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	// Attempts is how many times registration is tried before the server
+	// gives up on starting.
+	Attempts int
+}
+
+// DefaultBackoff is used by clients created with New.
+var DefaultBackoff = Backoff{
+	Initial:  500 * time.Millisecond,
+	Max:      30 * time.Second,
+	Attempts: 5,
+}
+
+func (b Backoff) delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 0; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	return min(d, b.Max)
+}
+
+// Client keeps the server listed in an Odyssey Registry: it registers on
+// start, heartbeats with the current player count and deregisters when
+// stopped.
+//
+// The registry API is:
+//
+//	POST   /servers                 Info      -> 201 Registration
+//	PUT    /servers/{id}/heartbeat  Heartbeat -> 204, 404 when unknown
+//	DELETE /servers/{id}                      -> 204
+type Client struct {
+	wg   *sync.WaitGroup
+	once sync.Once
+
+	base     *url.URL
+	http     *http.Client
+	info     Info
+	players  func() int
+	interval time.Duration
+	backoff  Backoff
+
+	// id is assigned by the registry. Owned by the heartbeat goroutine once
+	// started.
+	id string
+
+	// cancel ends the heartbeat goroutine, which closes done on exit.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a Client for the registry at base. players reports the number
+// of players currently connected.
+func New(base *url.URL, info Info, players func() int) *Client {
+	return &Client{
+		base:     base,
+		http:     &http.Client{Timeout: 10 * time.Second},
+		info:     info,
+		players:  players,
+		interval: DefaultHeartbeatInterval,
+		backoff:  DefaultBackoff,
+	}
+}
+
+// Start registers the server, retrying with backoff, and returns an error if
+// the registry cannot be reached. Once registered, heartbeats are sent until
+// Stop is called or ctx ends.
+func (c *Client) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	var startErr error
+	c.once.Do(func() {
+		if startErr = c.registerWithRetry(ctx); startErr != nil {
+			return
+		}
+		var runCtx context.Context
+		runCtx, c.cancel = context.WithCancel(ctx)
+		c.done = make(chan struct{})
+		c.wg = wg
+		c.wg.Add(1)
+		go func() {
+			c.run(runCtx)
+			close(c.done)
+			c.wg.Done()
+		}()
+	})
+	return startErr
+}
+
+// Stop ends the heartbeats and removes the server from the registry.
+func (c *Client) Stop(ctx context.Context) error {
+	if c.done == nil {
+		return nil
+	}
+	c.cancel()
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return c.deregister(ctx)
+}
+
+func (c *Client) registerWithRetry(ctx context.Context) error {
+	var err error
+	for attempt := 0; attempt < c.backoff.Attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff.delay(attempt-1)); err != nil {
+				return err
+			}
+		}
+		if err = c.register(ctx); err == nil {
+			slog.Info("registered with registry", "registry", c.base.String(), "id", c.id)
+			return nil
+		}
+		slog.Warn("registry registration failed", "registry", c.base.String(), "attempt", attempt+1, "error", err)
+	}
+	return fmt.Errorf("registering with %s: %w", c.base, err)
+}
+
+// run heartbeats until ctx is done. Failed heartbeats are retried with
+// backoff; if the registry has forgotten the server it is registered again.
+func (c *Client) run(ctx context.Context) {
+	failures := 0
+	wait := c.interval
+	for {
+		if err := sleep(ctx, wait); err != nil {
+			return
+		}
+
+		err := c.heartbeat(ctx)
+		if errors.Is(err, errUnknownServer) {
+			slog.Warn("registry lost registration, registering again", "id", c.id)
+			err = c.register(ctx)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("registry heartbeat failed", "error", err, "failures", failures+1)
+			wait = c.backoff.delay(failures)
+			failures++
+			continue
+		}
+		failures = 0
+		wait = c.interval
+	}
+}
+
+func (c *Client) register(ctx context.Context) error {
+	var reg Registration
+	if err := c.do(ctx, http.MethodPost, "servers", c.info, http.StatusCreated, &reg); err != nil {
+		return err
+	}
+	if reg.ID == "" {
+		return errors.New("registry returned no server id")
+	}
+	c.id = reg.ID
+	return nil
+}
+
+func (c *Client) heartbeat(ctx context.Context) error {
+	hb := Heartbeat{Players: c.players(), Capacity: c.info.Capacity}
+	err := c.do(ctx, http.MethodPut, "servers/"+url.PathEscape(c.id)+"/heartbeat", hb, http.StatusNoContent, nil)
+	var status *statusError
+	if errors.As(err, &status) && status.code == http.StatusNotFound {
+		return errUnknownServer
+	}
+	return err
+}
+
+// deregister removes the server from the registry.
+func (c *Client) deregister(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodDelete, "servers/"+url.PathEscape(c.id), nil, http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf("registry deregistration: %w", err)
+	}
+	slog.Info("deregistered from registry", "id", c.id)
+	return nil
+}
+
+// do sends body as JSON to path under the registry URL and decodes the reply
+// into out when it is non-nil.
+func (c *Client) do(ctx context.Context, method, path string, body any, want int, out any) error {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.base.JoinPath(path).String(), reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != want {
+		return &statusError{method: method, path: path, code: resp.StatusCode}
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// statusError reports a registry reply with an unexpected status code.
+type statusError struct {
+	method, path string
+	code         int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status %d %s", e.method, e.path, e.code, http.StatusText(e.code))
+}
+
+// sleep waits for d or until ctx is done, whichever is first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,191 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeRegistry is an in-memory stand-in for the registry API.
+type fakeRegistry struct {
+	mu           sync.Mutex
+	registered   []Info
+	heartbeats   []Heartbeat
+	deregistered []string
+	// known is the set of server IDs the registry currently lists.
+	known map[string]bool
+	// failRegister is how many registrations fail before one succeeds.
+	failRegister int
+	// failHeartbeat is how many heartbeats fail before one succeeds.
+	failHeartbeat int
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/servers":
+		if f.failRegister > 0 {
+			f.failRegister--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var info Info
+		json.NewDecoder(r.Body).Decode(&info)
+		f.registered = append(f.registered, info)
+		id := "server-" + string(rune('0'+len(f.registered)))
+		f.known[id] = true
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Registration{ID: id})
+	case r.Method == http.MethodPut && len(parts) == 3 && parts[2] == "heartbeat":
+		if f.failHeartbeat > 0 {
+			f.failHeartbeat--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !f.known[parts[1]] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var hb Heartbeat
+		json.NewDecoder(r.Body).Decode(&hb)
+		f.heartbeats = append(f.heartbeats, hb)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		delete(f.known, parts[1])
+		f.deregistered = append(f.deregistered, parts[1])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// forget drops every registration, as a restarted registry would.
+func (f *fakeRegistry) forget() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.known = make(map[string]bool)
+}
+
+func (f *fakeRegistry) counts() (registered, heartbeats, deregistered int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.registered), len(f.heartbeats), len(f.deregistered)
+}
+
+type ClientSuite struct {
+	suite.Suite
+	registry *fakeRegistry
+	server   *httptest.Server
+	players  atomic.Int64
+	client   *Client
+}
+
+func (s *ClientSuite) SetupTest() {
+	s.registry = &fakeRegistry{known: make(map[string]bool)}
+	s.server = httptest.NewServer(s.registry)
+	base, err := url.Parse(s.server.URL)
+	s.Require().NoError(err)
+
+	s.players.Store(0)
+	info := Info{Name: "Test", Version: "1.2.3", Ports: Ports{Meta: 8082, Network: 8080}, Capacity: 50}
+	s.client = New(base, info, func() int { return int(s.players.Load()) })
+	s.client.interval = 10 * time.Millisecond
+	s.client.backoff = Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond, Attempts: 3}
+}
+
+func (s *ClientSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClientSuite) TestLifecycle() {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	s.players.Store(7)
+
+	s.Require().NoError(s.client.Start(ctx, wg))
+	s.Equal([]Info{s.client.info}, s.registry.registered)
+
+	s.Eventually(func() bool {
+		_, heartbeats, _ := s.registry.counts()
+		return heartbeats >= 2
+	}, time.Second, 5*time.Millisecond)
+	s.registry.mu.Lock()
+	s.Equal(Heartbeat{Players: 7, Capacity: 50}, s.registry.heartbeats[0])
+	s.registry.mu.Unlock()
+
+	s.Require().NoError(s.client.Stop(context.Background()))
+	cancel()
+	wg.Wait()
+	s.Equal([]string{"server-1"}, s.registry.deregistered)
+}
+
+func (s *ClientSuite) TestRegistrationRetries() {
+	s.registry.failRegister = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.NoError(s.client.Start(ctx, &sync.WaitGroup{}))
+	registered, _, _ := s.registry.counts()
+	s.Equal(1, registered)
+}
+
+func (s *ClientSuite) TestRegistrationGivesUp() {
+	s.registry.failRegister = 3
+
+	err := s.client.Start(context.Background(), &sync.WaitGroup{})
+	s.ErrorContains(err, "503")
+}
+
+func (s *ClientSuite) TestUnreachableRegistry() {
+	s.server.Close()
+
+	s.Error(s.client.Start(context.Background(), &sync.WaitGroup{}))
+}
+
+func (s *ClientSuite) TestHeartbeatRecovers() {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	s.Require().NoError(s.client.Start(ctx, wg))
+
+	// Failed heartbeats are retried, and a registry that lost track of the
+	// server gets it registered again.
+	s.registry.mu.Lock()
+	s.registry.failHeartbeat = 2
+	s.registry.mu.Unlock()
+	s.registry.forget()
+
+	s.Eventually(func() bool {
+		registered, heartbeats, _ := s.registry.counts()
+		return registered == 2 && heartbeats >= 1
+	}, time.Second, 5*time.Millisecond)
+
+	s.Require().NoError(s.client.Stop(context.Background()))
+	cancel()
+	wg.Wait()
+	s.Equal([]string{"server-2"}, s.registry.deregistered)
+}
+
+func (s *ClientSuite) TestBackoffDelay() {
+	b := Backoff{Initial: time.Second, Max: 5 * time.Second}
+	s.Equal(time.Second, b.delay(0))
+	s.Equal(2*time.Second, b.delay(1))
+	s.Equal(4*time.Second, b.delay(2))
+	s.Equal(5*time.Second, b.delay(3))
+	s.Equal(5*time.Second, b.delay(30))
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
@@ -1,3 +1,4 @@
+// Package utils holds the JSON response helpers shared by the HTTP services.
 package utils
 
 import (
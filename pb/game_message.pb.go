@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.33.0
+// 	protoc-gen-go v1.34.2
 // 	protoc        v5.28.2
 // source: game_message.proto
 
@@ -20,77 +20,1118 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type MessageType int32
+// ProtocolVersion identifies the wire schema a GameMessage was built against.
+type ProtocolVersion int32
 
 const (
-	MessageType_MESSAGE_TYPE_UNSPECIFIED MessageType = 0
-	MessageType_MESSAGE_TYPE_JOIN_GAME   MessageType = 1
+	ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED ProtocolVersion = 0
+	ProtocolVersion_PROTOCOL_VERSION_1           ProtocolVersion = 1
 )
 
-// Enum value maps for MessageType.
+// Enum value maps for ProtocolVersion.
 var (
-	MessageType_name = map[int32]string{
-		0: "MESSAGE_TYPE_UNSPECIFIED",
-		1: "MESSAGE_TYPE_JOIN_GAME",
+	ProtocolVersion_name = map[int32]string{
+		0: "PROTOCOL_VERSION_UNSPECIFIED",
+		1: "PROTOCOL_VERSION_1",
 	}
-	MessageType_value = map[string]int32{
-		"MESSAGE_TYPE_UNSPECIFIED": 0,
-		"MESSAGE_TYPE_JOIN_GAME":   1,
+	ProtocolVersion_value = map[string]int32{
+		"PROTOCOL_VERSION_UNSPECIFIED": 0,
+		"PROTOCOL_VERSION_1":           1,
 	}
 )
 
-func (x MessageType) Enum() *MessageType {
-	p := new(MessageType)
+func (x ProtocolVersion) Enum() *ProtocolVersion {
+	p := new(ProtocolVersion)
 	*p = x
 	return p
 }
 
-func (x MessageType) String() string {
+func (x ProtocolVersion) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (MessageType) Descriptor() protoreflect.EnumDescriptor {
+func (ProtocolVersion) Descriptor() protoreflect.EnumDescriptor {
 	return file_game_message_proto_enumTypes[0].Descriptor()
 }
 
-func (MessageType) Type() protoreflect.EnumType {
+func (ProtocolVersion) Type() protoreflect.EnumType {
 	return &file_game_message_proto_enumTypes[0]
 }
 
-func (x MessageType) Number() protoreflect.EnumNumber {
+func (x ProtocolVersion) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use MessageType.Descriptor instead.
-func (MessageType) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use ProtocolVersion.Descriptor instead.
+func (ProtocolVersion) EnumDescriptor() ([]byte, []int) {
 	return file_game_message_proto_rawDescGZIP(), []int{0}
 }
 
+// Direction mirrors the cardinal directions used by maps, offset by one so
+// that the zero value means "not set".
+type Direction int32
+
+const (
+	Direction_DIRECTION_UNSPECIFIED Direction = 0
+	Direction_DIRECTION_NORTH       Direction = 1
+	Direction_DIRECTION_EAST        Direction = 2
+	Direction_DIRECTION_SOUTH       Direction = 3
+	Direction_DIRECTION_WEST        Direction = 4
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_UNSPECIFIED",
+		1: "DIRECTION_NORTH",
+		2: "DIRECTION_EAST",
+		3: "DIRECTION_SOUTH",
+		4: "DIRECTION_WEST",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_UNSPECIFIED": 0,
+		"DIRECTION_NORTH":       1,
+		"DIRECTION_EAST":        2,
+		"DIRECTION_SOUTH":       3,
+		"DIRECTION_WEST":        4,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_message_proto_enumTypes[1].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_game_message_proto_enumTypes[1]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{1}
+}
+
+// EntityKind identifies what an Entity represents to the client.
+type EntityKind int32
+
+const (
+	EntityKind_ENTITY_KIND_UNSPECIFIED EntityKind = 0
+	EntityKind_ENTITY_KIND_PLAYER      EntityKind = 1
+	EntityKind_ENTITY_KIND_NPC         EntityKind = 2
+	EntityKind_ENTITY_KIND_ITEM        EntityKind = 3
+)
+
+// Enum value maps for EntityKind.
+var (
+	EntityKind_name = map[int32]string{
+		0: "ENTITY_KIND_UNSPECIFIED",
+		1: "ENTITY_KIND_PLAYER",
+		2: "ENTITY_KIND_NPC",
+		3: "ENTITY_KIND_ITEM",
+	}
+	EntityKind_value = map[string]int32{
+		"ENTITY_KIND_UNSPECIFIED": 0,
+		"ENTITY_KIND_PLAYER":      1,
+		"ENTITY_KIND_NPC":         2,
+		"ENTITY_KIND_ITEM":        3,
+	}
+)
+
+func (x EntityKind) Enum() *EntityKind {
+	p := new(EntityKind)
+	*p = x
+	return p
+}
+
+func (x EntityKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_message_proto_enumTypes[2].Descriptor()
+}
+
+func (EntityKind) Type() protoreflect.EnumType {
+	return &file_game_message_proto_enumTypes[2]
+}
+
+func (x EntityKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityKind.Descriptor instead.
+func (EntityKind) EnumDescriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{2}
+}
+
+// ErrorCode classifies an Error sent by the server.
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED ErrorCode = 0
+	// The message could not be understood or is not valid in the current state.
+	ErrorCode_ERROR_CODE_BAD_REQUEST ErrorCode = 1
+	// The connection's credentials do not allow the requested action.
+	ErrorCode_ERROR_CODE_UNAUTHORIZED ErrorCode = 2
+	// The requested character is already in the world.
+	ErrorCode_ERROR_CODE_ALREADY_PLAYING ErrorCode = 3
+	// A movement request was refused; the client should resync its position.
+	ErrorCode_ERROR_CODE_MOVE_REJECTED ErrorCode = 4
+	// The client is sending messages faster than the server allows.
+	ErrorCode_ERROR_CODE_RATE_LIMITED ErrorCode = 5
+	ErrorCode_ERROR_CODE_INTERNAL     ErrorCode = 6
+	// The message names something, such as a player, that does not exist.
+	ErrorCode_ERROR_CODE_NOT_FOUND ErrorCode = 7
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_BAD_REQUEST",
+		2: "ERROR_CODE_UNAUTHORIZED",
+		3: "ERROR_CODE_ALREADY_PLAYING",
+		4: "ERROR_CODE_MOVE_REJECTED",
+		5: "ERROR_CODE_RATE_LIMITED",
+		6: "ERROR_CODE_INTERNAL",
+		7: "ERROR_CODE_NOT_FOUND",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":     0,
+		"ERROR_CODE_BAD_REQUEST":     1,
+		"ERROR_CODE_UNAUTHORIZED":    2,
+		"ERROR_CODE_ALREADY_PLAYING": 3,
+		"ERROR_CODE_MOVE_REJECTED":   4,
+		"ERROR_CODE_RATE_LIMITED":    5,
+		"ERROR_CODE_INTERNAL":        6,
+		"ERROR_CODE_NOT_FOUND":       7,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_message_proto_enumTypes[3].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_game_message_proto_enumTypes[3]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{3}
+}
+
+// ChatScope selects who a Chat is delivered to.
+type ChatScope int32
+
+const (
+	// Treated as CHAT_SCOPE_MAP when sent by a client.
+	ChatScope_CHAT_SCOPE_UNSPECIFIED ChatScope = 0
+	// Everyone who can see the sender's map.
+	ChatScope_CHAT_SCOPE_MAP ChatScope = 1
+	// A single player, named by Chat.target.
+	ChatScope_CHAT_SCOPE_WHISPER ChatScope = 2
+	// Everyone online in the sender's guild. Refused when the server has no
+	// guilds or the sender is in none.
+	ChatScope_CHAT_SCOPE_GUILD ChatScope = 3
+	// Every player on the server. Only sent by the server, on behalf of an
+	// administrator.
+	ChatScope_CHAT_SCOPE_ANNOUNCEMENT ChatScope = 4
+	// Text from the game itself, such as a tile script, to one player.
+	ChatScope_CHAT_SCOPE_SYSTEM ChatScope = 5
+)
+
+// Enum value maps for ChatScope.
+var (
+	ChatScope_name = map[int32]string{
+		0: "CHAT_SCOPE_UNSPECIFIED",
+		1: "CHAT_SCOPE_MAP",
+		2: "CHAT_SCOPE_WHISPER",
+		3: "CHAT_SCOPE_GUILD",
+		4: "CHAT_SCOPE_ANNOUNCEMENT",
+		5: "CHAT_SCOPE_SYSTEM",
+	}
+	ChatScope_value = map[string]int32{
+		"CHAT_SCOPE_UNSPECIFIED":  0,
+		"CHAT_SCOPE_MAP":          1,
+		"CHAT_SCOPE_WHISPER":      2,
+		"CHAT_SCOPE_GUILD":        3,
+		"CHAT_SCOPE_ANNOUNCEMENT": 4,
+		"CHAT_SCOPE_SYSTEM":       5,
+	}
+)
+
+func (x ChatScope) Enum() *ChatScope {
+	p := new(ChatScope)
+	*p = x
+	return p
+}
+
+func (x ChatScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_message_proto_enumTypes[4].Descriptor()
+}
+
+func (ChatScope) Type() protoreflect.EnumType {
+	return &file_game_message_proto_enumTypes[4]
+}
+
+func (x ChatScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatScope.Descriptor instead.
+func (ChatScope) EnumDescriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{4}
+}
+
+// DisconnectReason explains why a connection is being closed.
+type DisconnectReason int32
+
+const (
+	DisconnectReason_DISCONNECT_REASON_UNSPECIFIED     DisconnectReason = 0
+	DisconnectReason_DISCONNECT_REASON_CLIENT_QUIT     DisconnectReason = 1
+	DisconnectReason_DISCONNECT_REASON_SERVER_SHUTDOWN DisconnectReason = 2
+	DisconnectReason_DISCONNECT_REASON_KICKED          DisconnectReason = 3
+	// Another connection logged in with the same character.
+	DisconnectReason_DISCONNECT_REASON_DUPLICATE_LOGIN DisconnectReason = 4
+	DisconnectReason_DISCONNECT_REASON_TIMEOUT         DisconnectReason = 5
+)
+
+// Enum value maps for DisconnectReason.
+var (
+	DisconnectReason_name = map[int32]string{
+		0: "DISCONNECT_REASON_UNSPECIFIED",
+		1: "DISCONNECT_REASON_CLIENT_QUIT",
+		2: "DISCONNECT_REASON_SERVER_SHUTDOWN",
+		3: "DISCONNECT_REASON_KICKED",
+		4: "DISCONNECT_REASON_DUPLICATE_LOGIN",
+		5: "DISCONNECT_REASON_TIMEOUT",
+	}
+	DisconnectReason_value = map[string]int32{
+		"DISCONNECT_REASON_UNSPECIFIED":     0,
+		"DISCONNECT_REASON_CLIENT_QUIT":     1,
+		"DISCONNECT_REASON_SERVER_SHUTDOWN": 2,
+		"DISCONNECT_REASON_KICKED":          3,
+		"DISCONNECT_REASON_DUPLICATE_LOGIN": 4,
+		"DISCONNECT_REASON_TIMEOUT":         5,
+	}
+)
+
+func (x DisconnectReason) Enum() *DisconnectReason {
+	p := new(DisconnectReason)
+	*p = x
+	return p
+}
+
+func (x DisconnectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisconnectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_message_proto_enumTypes[5].Descriptor()
+}
+
+func (DisconnectReason) Type() protoreflect.EnumType {
+	return &file_game_message_proto_enumTypes[5]
+}
+
+func (x DisconnectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisconnectReason.Descriptor instead.
+func (DisconnectReason) EnumDescriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{5}
+}
+
+// GameMessage is the envelope for every frame on the wire.
 type GameMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type MessageType `protobuf:"varint,1,opt,name=type,proto3,enum=MessageType" json:"type,omitempty"`
+	Version ProtocolVersion `protobuf:"varint,2,opt,name=version,proto3,enum=odyssey.v1.ProtocolVersion" json:"version,omitempty"`
+	// Types that are assignable to Payload:
+	//	*GameMessage_JoinGame
+	//	*GameMessage_Move
+	//	*GameMessage_Chat
+	//	*GameMessage_MapChange
+	//	*GameMessage_EntitySpawn
+	//	*GameMessage_EntityDespawn
+	//	*GameMessage_Error
+	//	*GameMessage_Disconnect
+	//	*GameMessage_Interact
+	//	*GameMessage_TileChange
+	Payload isGameMessage_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *GameMessage) Reset() {
+	*x = GameMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameMessage) ProtoMessage() {}
+
+func (x *GameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameMessage.ProtoReflect.Descriptor instead.
+func (*GameMessage) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GameMessage) GetVersion() ProtocolVersion {
+	if x != nil {
+		return x.Version
+	}
+	return ProtocolVersion_PROTOCOL_VERSION_UNSPECIFIED
+}
+
+func (m *GameMessage) GetPayload() isGameMessage_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *GameMessage) GetJoinGame() *JoinGame {
+	if x, ok := x.GetPayload().(*GameMessage_JoinGame); ok {
+		return x.JoinGame
+	}
+	return nil
+}
+
+func (x *GameMessage) GetMove() *Move {
+	if x, ok := x.GetPayload().(*GameMessage_Move); ok {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *GameMessage) GetChat() *Chat {
+	if x, ok := x.GetPayload().(*GameMessage_Chat); ok {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *GameMessage) GetMapChange() *MapChange {
+	if x, ok := x.GetPayload().(*GameMessage_MapChange); ok {
+		return x.MapChange
+	}
+	return nil
+}
+
+func (x *GameMessage) GetEntitySpawn() *EntitySpawn {
+	if x, ok := x.GetPayload().(*GameMessage_EntitySpawn); ok {
+		return x.EntitySpawn
+	}
+	return nil
+}
+
+func (x *GameMessage) GetEntityDespawn() *EntityDespawn {
+	if x, ok := x.GetPayload().(*GameMessage_EntityDespawn); ok {
+		return x.EntityDespawn
+	}
+	return nil
+}
+
+func (x *GameMessage) GetError() *Error {
+	if x, ok := x.GetPayload().(*GameMessage_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *GameMessage) GetDisconnect() *Disconnect {
+	if x, ok := x.GetPayload().(*GameMessage_Disconnect); ok {
+		return x.Disconnect
+	}
+	return nil
+}
+
+func (x *GameMessage) GetInteract() *Interact {
+	if x, ok := x.GetPayload().(*GameMessage_Interact); ok {
+		return x.Interact
+	}
+	return nil
+}
+
+func (x *GameMessage) GetTileChange() *TileChange {
+	if x, ok := x.GetPayload().(*GameMessage_TileChange); ok {
+		return x.TileChange
+	}
+	return nil
+}
+
+type isGameMessage_Payload interface {
+	isGameMessage_Payload()
+}
+
+type GameMessage_JoinGame struct {
+	JoinGame *JoinGame `protobuf:"bytes,10,opt,name=join_game,json=joinGame,proto3,oneof"`
+}
+
+type GameMessage_Move struct {
+	Move *Move `protobuf:"bytes,11,opt,name=move,proto3,oneof"`
+}
+
+type GameMessage_Chat struct {
+	Chat *Chat `protobuf:"bytes,12,opt,name=chat,proto3,oneof"`
+}
+
+type GameMessage_MapChange struct {
+	MapChange *MapChange `protobuf:"bytes,13,opt,name=map_change,json=mapChange,proto3,oneof"`
+}
+
+type GameMessage_EntitySpawn struct {
+	EntitySpawn *EntitySpawn `protobuf:"bytes,14,opt,name=entity_spawn,json=entitySpawn,proto3,oneof"`
+}
+
+type GameMessage_EntityDespawn struct {
+	EntityDespawn *EntityDespawn `protobuf:"bytes,15,opt,name=entity_despawn,json=entityDespawn,proto3,oneof"`
+}
+
+type GameMessage_Error struct {
+	Error *Error `protobuf:"bytes,16,opt,name=error,proto3,oneof"`
+}
+
+type GameMessage_Disconnect struct {
+	Disconnect *Disconnect `protobuf:"bytes,17,opt,name=disconnect,proto3,oneof"`
+}
+
+type GameMessage_Interact struct {
+	Interact *Interact `protobuf:"bytes,18,opt,name=interact,proto3,oneof"`
+}
+
+type GameMessage_TileChange struct {
+	TileChange *TileChange `protobuf:"bytes,19,opt,name=tile_change,json=tileChange,proto3,oneof"`
+}
+
+func (*GameMessage_JoinGame) isGameMessage_Payload() {}
+
+func (*GameMessage_Move) isGameMessage_Payload() {}
+
+func (*GameMessage_Chat) isGameMessage_Payload() {}
+
+func (*GameMessage_MapChange) isGameMessage_Payload() {}
+
+func (*GameMessage_EntitySpawn) isGameMessage_Payload() {}
+
+func (*GameMessage_EntityDespawn) isGameMessage_Payload() {}
+
+func (*GameMessage_Error) isGameMessage_Payload() {}
+
+func (*GameMessage_Disconnect) isGameMessage_Payload() {}
+
+func (*GameMessage_Interact) isGameMessage_Payload() {}
+
+func (*GameMessage_TileChange) isGameMessage_Payload() {}
+
+// Position is a tile location within a map.
+type Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MapId int32 `protobuf:"varint,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	X     int32 `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int32 `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Position) GetMapId() int32 {
+	if x != nil {
+		return x.MapId
+	}
+	return 0
+}
+
+func (x *Position) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Position) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// Entity is anything the client renders on a map.
+type Entity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind     EntityKind `protobuf:"varint,2,opt,name=kind,proto3,enum=odyssey.v1.EntityKind" json:"kind,omitempty"`
+	Name     string     `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Position *Position  `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	Facing   Direction  `protobuf:"varint,5,opt,name=facing,proto3,enum=odyssey.v1.Direction" json:"facing,omitempty"`
+}
+
+func (x *Entity) Reset() {
+	*x = Entity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Entity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entity) ProtoMessage() {}
+
+func (x *Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entity.ProtoReflect.Descriptor instead.
+func (*Entity) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Entity) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Entity) GetKind() EntityKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EntityKind_ENTITY_KIND_UNSPECIFIED
+}
+
+func (x *Entity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Entity) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Entity) GetFacing() Direction {
+	if x != nil {
+		return x.Facing
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+// JoinGame is sent by the client to put a character into the world.
+type JoinGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CharacterId string `protobuf:"bytes,1,opt,name=character_id,json=characterId,proto3" json:"character_id,omitempty"`
+}
+
+func (x *JoinGame) Reset() {
+	*x = JoinGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGame) ProtoMessage() {}
+
+func (x *JoinGame) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGame.ProtoReflect.Descriptor instead.
+func (*JoinGame) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinGame) GetCharacterId() string {
+	if x != nil {
+		return x.CharacterId
+	}
+	return ""
+}
+
+// Move is sent by the client to request a one tile step, and by the server to
+// announce an entity's authoritative position.
+type Move struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntityId  uint64    `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Direction Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=odyssey.v1.Direction" json:"direction,omitempty"`
+	Position  *Position `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	// Client chosen counter echoed back by the server so the client can match
+	// acknowledgements and resyncs to its predictions.
+	Sequence uint32 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Move) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *Move) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *Move) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Move) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// Interact is sent by the client when the player interacts with the tile it
+// is facing.
+type Interact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Interact) Reset() {
+	*x = Interact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interact) ProtoMessage() {}
+
+func (x *Interact) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interact.ProtoReflect.Descriptor instead.
+func (*Interact) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{5}
+}
+
+// Chat carries a line of text. Clients send scope, text and, for whispers,
+// target; the server fills in the sender before delivering it.
+type Chat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntityId   uint64    `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	SenderName string    `protobuf:"bytes,2,opt,name=sender_name,json=senderName,proto3" json:"sender_name,omitempty"`
+	Text       string    `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Scope      ChatScope `protobuf:"varint,4,opt,name=scope,proto3,enum=odyssey.v1.ChatScope" json:"scope,omitempty"`
+	// Character name of the whisper recipient.
+	Target string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Chat) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *Chat) GetSenderName() string {
+	if x != nil {
+		return x.SenderName
+	}
+	return ""
+}
+
+func (x *Chat) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Chat) GetScope() ChatScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ChatScope_CHAT_SCOPE_UNSPECIFIED
+}
+
+func (x *Chat) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+// MapChange tells the client it is now on a different map (or was just placed
+// in the world) along with everything currently visible there.
+type MapChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MapId int32 `protobuf:"varint,1,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	// Version of the map data; clients refetch the map when it differs from
+	// their cached copy.
+	MapVersion int32 `protobuf:"varint,2,opt,name=map_version,json=mapVersion,proto3" json:"map_version,omitempty"`
+	// The receiving client's own entity.
+	EntityId uint64    `protobuf:"varint,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Position *Position `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	Entities []*Entity `protobuf:"bytes,5,rep,name=entities,proto3" json:"entities,omitempty"`
+}
+
+func (x *MapChange) Reset() {
+	*x = MapChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapChange) ProtoMessage() {}
+
+func (x *MapChange) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapChange.ProtoReflect.Descriptor instead.
+func (*MapChange) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MapChange) GetMapId() int32 {
+	if x != nil {
+		return x.MapId
+	}
+	return 0
+}
+
+func (x *MapChange) GetMapVersion() int32 {
+	if x != nil {
+		return x.MapVersion
+	}
+	return 0
+}
+
+func (x *MapChange) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *MapChange) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *MapChange) GetEntities() []*Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+// TileChange announces that a tile's attributes changed while the server is
+// running. It carries the tile's full attribute set.
+type TileChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position   *Position         `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Attributes map[string]string `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *TileChange) Reset() {
+	*x = TileChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TileChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TileChange) ProtoMessage() {}
+
+func (x *TileChange) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TileChange.ProtoReflect.Descriptor instead.
+func (*TileChange) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TileChange) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *TileChange) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// EntitySpawn announces an entity appearing on the client's map.
+type EntitySpawn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entity *Entity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
 }
 
-func (x *GameMessage) Reset() {
-	*x = GameMessage{}
+func (x *EntitySpawn) Reset() {
+	*x = EntitySpawn{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_message_proto_msgTypes[0]
+		mi := &file_game_message_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GameMessage) String() string {
+func (x *EntitySpawn) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameMessage) ProtoMessage() {}
+func (*EntitySpawn) ProtoMessage() {}
 
-func (x *GameMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_game_message_proto_msgTypes[0]
+func (x *EntitySpawn) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,31 +1142,368 @@ func (x *GameMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameMessage.ProtoReflect.Descriptor instead.
-func (*GameMessage) Descriptor() ([]byte, []int) {
-	return file_game_message_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use EntitySpawn.ProtoReflect.Descriptor instead.
+func (*EntitySpawn) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EntitySpawn) GetEntity() *Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+// EntityDespawn announces an entity leaving the client's map.
+type EntityDespawn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntityId uint64 `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+}
+
+func (x *EntityDespawn) Reset() {
+	*x = EntityDespawn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityDespawn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityDespawn) ProtoMessage() {}
+
+func (x *EntityDespawn) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityDespawn.ProtoReflect.Descriptor instead.
+func (*EntityDespawn) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EntityDespawn) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+// Error reports a problem handling a client message. The connection stays
+// open.
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    ErrorCode `protobuf:"varint,1,opt,name=code,proto3,enum=odyssey.v1.ErrorCode" json:"code,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Error) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Disconnect is the last message sent before a connection is closed.
+type Disconnect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason  DisconnectReason `protobuf:"varint,1,opt,name=reason,proto3,enum=odyssey.v1.DisconnectReason" json:"reason,omitempty"`
+	Message string           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Disconnect) Reset() {
+	*x = Disconnect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_message_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Disconnect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Disconnect) ProtoMessage() {}
+
+func (x *Disconnect) ProtoReflect() protoreflect.Message {
+	mi := &file_game_message_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Disconnect.ProtoReflect.Descriptor instead.
+func (*Disconnect) Descriptor() ([]byte, []int) {
+	return file_game_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Disconnect) GetReason() DisconnectReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DisconnectReason_DISCONNECT_REASON_UNSPECIFIED
 }
 
-func (x *GameMessage) GetType() MessageType {
+func (x *Disconnect) GetMessage() string {
 	if x != nil {
-		return x.Type
+		return x.Message
 	}
-	return MessageType_MESSAGE_TYPE_UNSPECIFIED
+	return ""
 }
 
 var File_game_message_proto protoreflect.FileDescriptor
 
 var file_game_message_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2f, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x0c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x2a, 0x47, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
-	0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x10, 0x01, 0x42, 0x06,
-	0x5a, 0x04, 0x2e, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31,
+	0x22, 0xee, 0x04, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x35, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1b, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x5f,
+	0x67, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65,
+	0x48, 0x00, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04,
+	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x6d, 0x6f, 0x76, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x63, 0x68, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x68, 0x61, 0x74, 0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x36, 0x0a, 0x0a,
+	0x6d, 0x61, 0x70, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x70, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x6d, 0x61, 0x70, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x73,
+	0x70, 0x61, 0x77, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x70,
+	0x61, 0x77, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x70, 0x61,
+	0x77, 0x6e, 0x12, 0x42, 0x0a, 0x0e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x65, 0x73,
+	0x70, 0x61, 0x77, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x44, 0x65,
+	0x73, 0x70, 0x61, 0x77, 0x6e, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x44,
+	0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x38, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x61, 0x63, 0x74, 0x48, 0x00, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x12,
+	0x39, 0x0a, 0x0b, 0x74, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a,
+	0x74, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x22, 0x3d, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a,
+	0x06, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6d,
+	0x61, 0x70, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79,
+	0x22, 0xb9, 0x01, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6f, 0x64, 0x79, 0x73,
+	0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4b, 0x69, 0x6e,
+	0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a,
+	0x06, 0x66, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x22, 0x2d, 0x0a, 0x08,
+	0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0xa6, 0x01, 0x0a, 0x04,
+	0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49,
+	0x64, 0x12, 0x33, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73,
+	0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x22, 0x0a, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74,
+	0x22, 0x9d, 0x01, 0x0a, 0x04, 0x43, 0x68, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x22, 0xc2, 0x01, 0x0a, 0x09, 0x4d, 0x61, 0x70, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x15,
+	0x0a, 0x06, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6d, 0x61, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x70, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x70, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x0a, 0x54, 0x69, 0x6c, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x39, 0x0a,
+	0x0b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x12, 0x2a, 0x0a, 0x06,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f,
+	0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x2c, 0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x22, 0x4c, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x29, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x5c, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6f, 0x64, 0x79, 0x73, 0x73, 0x65, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2a, 0x4b, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f,
+	0x4c, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x54, 0x4f,
+	0x43, 0x4f, 0x4c, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x31, 0x10, 0x01, 0x2a,
+	0x78, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x15,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x52, 0x45, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x52, 0x54, 0x48, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x41, 0x53, 0x54, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x4f,
+	0x55, 0x54, 0x48, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x57, 0x45, 0x53, 0x54, 0x10, 0x04, 0x2a, 0x6c, 0x0a, 0x0a, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4e, 0x54, 0x49, 0x54,
+	0x59, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f,
+	0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x4e, 0x50, 0x43, 0x10,
+	0x02, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x03, 0x2a, 0xee, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x42, 0x41, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x01, 0x12, 0x1b, 0x0a,
+	0x17, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x41, 0x55,
+	0x54, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59,
+	0x5f, 0x50, 0x4c, 0x41, 0x59, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x52, 0x45,
+	0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49,
+	0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x06, 0x12, 0x18,
+	0x0a, 0x14, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x54,
+	0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x07, 0x2a, 0x9d, 0x01, 0x0a, 0x09, 0x43, 0x68, 0x61,
+	0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x53,
+	0x43, 0x4f, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45,
+	0x5f, 0x4d, 0x41, 0x50, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x53,
+	0x43, 0x4f, 0x50, 0x45, 0x5f, 0x57, 0x48, 0x49, 0x53, 0x50, 0x45, 0x52, 0x10, 0x02, 0x12, 0x14,
+	0x0a, 0x10, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x47, 0x55, 0x49,
+	0x4c, 0x44, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x53, 0x43, 0x4f,
+	0x50, 0x45, 0x5f, 0x41, 0x4e, 0x4e, 0x4f, 0x55, 0x4e, 0x43, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10,
+	0x04, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f,
+	0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x05, 0x2a, 0xe3, 0x01, 0x0a, 0x10, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
+	0x1d, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x21, 0x0a, 0x1d, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52,
+	0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x51, 0x55, 0x49,
+	0x54, 0x10, 0x01, 0x12, 0x25, 0x0a, 0x21, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
+	0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f,
+	0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x49,
+	0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x4b, 0x49, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x12, 0x25, 0x0a, 0x21, 0x44, 0x49, 0x53, 0x43,
+	0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x44, 0x55,
+	0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x10, 0x04, 0x12,
+	0x1d, 0x0a, 0x19, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x05, 0x42, 0x29,
+	0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4f, 0x64, 0x79,
+	0x73, 0x73, 0x65, 0x79, 0x2d, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x63, 0x2f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -140,19 +1518,60 @@ func file_game_message_proto_rawDescGZIP() []byte {
 	return file_game_message_proto_rawDescData
 }
 
-var file_game_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_game_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_game_message_proto_goTypes = []interface{}{
-	(MessageType)(0),    // 0: MessageType
-	(*GameMessage)(nil), // 1: GameMessage
+var file_game_message_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_game_message_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_game_message_proto_goTypes = []any{
+	(ProtocolVersion)(0),  // 0: odyssey.v1.ProtocolVersion
+	(Direction)(0),        // 1: odyssey.v1.Direction
+	(EntityKind)(0),       // 2: odyssey.v1.EntityKind
+	(ErrorCode)(0),        // 3: odyssey.v1.ErrorCode
+	(ChatScope)(0),        // 4: odyssey.v1.ChatScope
+	(DisconnectReason)(0), // 5: odyssey.v1.DisconnectReason
+	(*GameMessage)(nil),   // 6: odyssey.v1.GameMessage
+	(*Position)(nil),      // 7: odyssey.v1.Position
+	(*Entity)(nil),        // 8: odyssey.v1.Entity
+	(*JoinGame)(nil),      // 9: odyssey.v1.JoinGame
+	(*Move)(nil),          // 10: odyssey.v1.Move
+	(*Interact)(nil),      // 11: odyssey.v1.Interact
+	(*Chat)(nil),          // 12: odyssey.v1.Chat
+	(*MapChange)(nil),     // 13: odyssey.v1.MapChange
+	(*TileChange)(nil),    // 14: odyssey.v1.TileChange
+	(*EntitySpawn)(nil),   // 15: odyssey.v1.EntitySpawn
+	(*EntityDespawn)(nil), // 16: odyssey.v1.EntityDespawn
+	(*Error)(nil),         // 17: odyssey.v1.Error
+	(*Disconnect)(nil),    // 18: odyssey.v1.Disconnect
+	nil,                   // 19: odyssey.v1.TileChange.AttributesEntry
 }
 var file_game_message_proto_depIdxs = []int32{
-	0, // 0: GameMessage.type:type_name -> MessageType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: odyssey.v1.GameMessage.version:type_name -> odyssey.v1.ProtocolVersion
+	9,  // 1: odyssey.v1.GameMessage.join_game:type_name -> odyssey.v1.JoinGame
+	10, // 2: odyssey.v1.GameMessage.move:type_name -> odyssey.v1.Move
+	12, // 3: odyssey.v1.GameMessage.chat:type_name -> odyssey.v1.Chat
+	13, // 4: odyssey.v1.GameMessage.map_change:type_name -> odyssey.v1.MapChange
+	15, // 5: odyssey.v1.GameMessage.entity_spawn:type_name -> odyssey.v1.EntitySpawn
+	16, // 6: odyssey.v1.GameMessage.entity_despawn:type_name -> odyssey.v1.EntityDespawn
+	17, // 7: odyssey.v1.GameMessage.error:type_name -> odyssey.v1.Error
+	18, // 8: odyssey.v1.GameMessage.disconnect:type_name -> odyssey.v1.Disconnect
+	11, // 9: odyssey.v1.GameMessage.interact:type_name -> odyssey.v1.Interact
+	14, // 10: odyssey.v1.GameMessage.tile_change:type_name -> odyssey.v1.TileChange
+	2,  // 11: odyssey.v1.Entity.kind:type_name -> odyssey.v1.EntityKind
+	7,  // 12: odyssey.v1.Entity.position:type_name -> odyssey.v1.Position
+	1,  // 13: odyssey.v1.Entity.facing:type_name -> odyssey.v1.Direction
+	1,  // 14: odyssey.v1.Move.direction:type_name -> odyssey.v1.Direction
+	7,  // 15: odyssey.v1.Move.position:type_name -> odyssey.v1.Position
+	4,  // 16: odyssey.v1.Chat.scope:type_name -> odyssey.v1.ChatScope
+	7,  // 17: odyssey.v1.MapChange.position:type_name -> odyssey.v1.Position
+	8,  // 18: odyssey.v1.MapChange.entities:type_name -> odyssey.v1.Entity
+	7,  // 19: odyssey.v1.TileChange.position:type_name -> odyssey.v1.Position
+	19, // 20: odyssey.v1.TileChange.attributes:type_name -> odyssey.v1.TileChange.AttributesEntry
+	8,  // 21: odyssey.v1.EntitySpawn.entity:type_name -> odyssey.v1.Entity
+	3,  // 22: odyssey.v1.Error.code:type_name -> odyssey.v1.ErrorCode
+	5,  // 23: odyssey.v1.Disconnect.reason:type_name -> odyssey.v1.DisconnectReason
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_game_message_proto_init() }
@@ -161,7 +1580,7 @@ func file_game_message_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_game_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_game_message_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*GameMessage); i {
 			case 0:
 				return &v.state
@@ -173,14 +1592,170 @@ func file_game_message_proto_init() {
 				return nil
 			}
 		}
+		file_game_message_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Entity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*JoinGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Interact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Chat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*MapChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*TileChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*EntitySpawn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*EntityDespawn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_message_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Disconnect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_game_message_proto_msgTypes[0].OneofWrappers = []any{
+		(*GameMessage_JoinGame)(nil),
+		(*GameMessage_Move)(nil),
+		(*GameMessage_Chat)(nil),
+		(*GameMessage_MapChange)(nil),
+		(*GameMessage_EntitySpawn)(nil),
+		(*GameMessage_EntityDespawn)(nil),
+		(*GameMessage_Error)(nil),
+		(*GameMessage_Disconnect)(nil),
+		(*GameMessage_Interact)(nil),
+		(*GameMessage_TileChange)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_game_message_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   1,
+			NumEnums:      6,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
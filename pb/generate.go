@@ -0,0 +1,7 @@
+// Package pb contains the generated Go bindings for the realtime client/server
+// protocol defined in game_message.proto.
+//
+// Regenerate after editing the schema with `make proto` (or `go generate ./pb`).
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative game_message.proto
@@ -11,6 +11,8 @@ import { GuildsModule } from './modules/guilds'
 import { ScriptsModule } from './modules/scripts'
 import { TitleBarProvider } from './contexts/TitleBarContext'
 import { NavigationProvider } from './contexts/NavigationContext'
+import { useSession } from './contexts/SessionContext'
+import LoginScreen from './components/LoginScreen'
 
 function getModuleFromPath(): ModuleType {
     const path = window.location.pathname
@@ -30,6 +32,7 @@ function getPathFromModule(module: ModuleType): string {
 }
 
 export default function App() {
+    const { session } = useSession()
     const [currentModule, setCurrentModule] = useState<ModuleType>(getModuleFromPath())
 
     // Listen to browser back/forward navigation
@@ -70,6 +73,11 @@ export default function App() {
         }
     }
 
+    // The admin API refuses requests without a session, so log in first.
+    if (!session) {
+        return <LoginScreen />
+    }
+
     return (
         <TitleBarProvider>
             <NavigationProvider navigateTo={navigateTo}>
@@ -1,3 +1,5 @@
+import { authFetch } from './session'
+
 export interface MapLinks {
     north?: number
     east?: number
@@ -37,18 +39,18 @@ function ok(res: Response) {
 export async function listMaps(query?: string): Promise<GameMap[]> {
     const u = new URL('/admin/maps', window.location.origin)
     if (query) u.searchParams.set('q', query)
-    const res = await fetch(u.toString(), { method: 'GET' }).then(ok)
+    const res = await authFetch(u.toString(), { method: 'GET' }).then(ok)
     return res.json()
 }
 
 export async function getMap(id: number): Promise<GameMap> {
-    const res = await fetch(`/admin/maps/${id}`, { method: 'GET' }).then(ok)
+    const res = await authFetch(`/admin/maps/${id}`, { method: 'GET' }).then(ok)
     return res.json()
 }
 
 export async function createMap(name: string): Promise<GameMap> {
     // minimal payload: only name; server fills defaults via NewMap
-    const res = await fetch('/admin/maps', {
+    const res = await authFetch('/admin/maps', {
         method: 'POST',
         headers: JSON_HEADERS,
         body: JSON.stringify({ name }),
@@ -57,9 +59,45 @@ export async function createMap(name: string): Promise<GameMap> {
 }
 
 export async function updateMap(map: GameMap): Promise<void> {
-    await fetch(`/admin/maps/${map.id}`, {
+    await authFetch(`/admin/maps/${map.id}`, {
         method: 'PUT',
         headers: JSON_HEADERS,
         body: JSON.stringify(map),
     }).then(ok)
 }
+
+export interface TileEdit {
+    x: number
+    y: number
+    tile: MapTile
+}
+
+export interface TilesResult {
+    version: number
+    tiles_changed: number
+}
+
+export async function getTile(mapId: number, x: number, y: number): Promise<MapTile> {
+    const res = await authFetch(`/admin/maps/${mapId}/tiles/${x}/${y}`, { method: 'GET' }).then(ok)
+    return res.json()
+}
+
+export async function putTile(mapId: number, x: number, y: number, tile: MapTile): Promise<MapTile> {
+    const res = await authFetch(`/admin/maps/${mapId}/tiles/${x}/${y}`, {
+        method: 'PUT',
+        headers: JSON_HEADERS,
+        body: JSON.stringify(tile),
+    }).then(ok)
+    return res.json()
+}
+
+// patchTiles saves every edit in one new map version, or none of them.
+// Passing version makes the server refuse with 409 if the map has moved on.
+export async function patchTiles(mapId: number, edits: TileEdit[], version?: number): Promise<TilesResult> {
+    const res = await authFetch(`/admin/maps/${mapId}/tiles`, {
+        method: 'PATCH',
+        headers: JSON_HEADERS,
+        body: JSON.stringify({ version, edits }),
+    }).then(ok)
+    return res.json()
+}
@@ -0,0 +1,91 @@
+export interface Administrator {
+    id: string
+    username: string
+    role: string
+    disabled: boolean
+    created_at: string
+}
+
+export interface Session {
+    token: string
+    expires_at: string
+    administrator: Administrator
+}
+
+// The session lives in sessionStorage, so closing the tab logs out just as a
+// server restart does.
+const STORAGE_KEY = 'odyssey-admin-session'
+
+let current: Session | null = load()
+const listeners = new Set<(session: Session | null) => void>()
+
+function load(): Session | null {
+    const raw = window.sessionStorage.getItem(STORAGE_KEY)
+    if (!raw) return null
+    try {
+        const session: Session = JSON.parse(raw)
+        return new Date(session.expires_at) > new Date() ? session : null
+    } catch {
+        return null
+    }
+}
+
+function setSession(session: Session | null) {
+    current = session
+    if (session) window.sessionStorage.setItem(STORAGE_KEY, JSON.stringify(session))
+    else window.sessionStorage.removeItem(STORAGE_KEY)
+    listeners.forEach((listener) => listener(session))
+}
+
+export function getSession(): Session | null {
+    return current
+}
+
+// onSessionChange calls listener whenever the user logs in or out, or the
+// server ends the session. It returns a function that stops listening.
+export function onSessionChange(listener: (session: Session | null) => void): () => void {
+    listeners.add(listener)
+    return () => {
+        listeners.delete(listener)
+    }
+}
+
+async function errorMessage(res: Response): Promise<string> {
+    try {
+        const body = await res.json()
+        if (body?.message) return body.message
+    } catch {
+        // fall through to the status
+    }
+    return `HTTP ${res.status}`
+}
+
+export async function login(username: string, password: string): Promise<Session> {
+    const res = await fetch('/admin/sessions', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ username, password }),
+    })
+    if (!res.ok) throw new Error(await errorMessage(res))
+    const session: Session = await res.json()
+    setSession(session)
+    return session
+}
+
+export async function logout(): Promise<void> {
+    try {
+        await authFetch('/admin/sessions/current', { method: 'DELETE' })
+    } finally {
+        setSession(null)
+    }
+}
+
+// authFetch is fetch with the session's bearer token. A 401 means the session
+// has expired or been revoked, so it is forgotten and the login screen shows.
+export async function authFetch(input: string, init: RequestInit = {}): Promise<Response> {
+    const headers = new Headers(init.headers)
+    if (current) headers.set('Authorization', `Bearer ${current.token}`)
+    const res = await fetch(input, { ...init, headers })
+    if (res.status === 401) setSession(null)
+    return res
+}
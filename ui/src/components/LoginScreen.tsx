@@ -0,0 +1,42 @@
+import React, { useState } from 'react'
+import { useSession } from '../contexts/SessionContext'
+
+export default function LoginScreen() {
+    const { login } = useSession()
+    const [username, setUsername] = useState('')
+    const [password, setPassword] = useState('')
+    const [error, setError] = useState<string | null>(null)
+    const [busy, setBusy] = useState(false)
+
+    const onSubmit = async (e: React.FormEvent) => {
+        e.preventDefault()
+        setBusy(true)
+        setError(null)
+        try {
+            await login(username, password)
+        } catch (err) {
+            setError(err instanceof Error ? err.message : 'Login failed')
+            setBusy(false)
+        }
+    }
+
+    return (
+        <div className="login-screen">
+            <form className="login-form" onSubmit={onSubmit}>
+                <h1>Server Admin</h1>
+                <label>
+                    Username
+                    <input value={username} onChange={(e) => setUsername(e.target.value)} autoComplete="username" autoFocus />
+                </label>
+                <label>
+                    Password
+                    <input type="password" value={password} onChange={(e) => setPassword(e.target.value)} autoComplete="current-password" />
+                </label>
+                {error && <div className="login-error">{error}</div>}
+                <button type="submit" disabled={busy || !username || !password}>
+                    Log in
+                </button>
+            </form>
+        </div>
+    )
+}
@@ -1,8 +1,10 @@
 import React from 'react'
 import { useTitleBar } from '../contexts/TitleBarContext'
+import { useSession } from '../contexts/SessionContext'
 
 export default function TitleBar() {
     const { title } = useTitleBar()
+    const { session, logout } = useSession()
 
     return (
         <div className="title-bar">
@@ -23,7 +25,12 @@ export default function TitleBar() {
                         <line x1="12" y1="17" x2="12.01" y2="17" />
                     </svg>
                 </button>
-                <button className="icon-button" aria-label="Account">
+                <button
+                    className="icon-button"
+                    aria-label="Log out"
+                    title={session ? `Log out ${session.administrator.username}` : undefined}
+                    onClick={() => logout()}
+                >
                     <svg width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" strokeWidth="2">
                         <path d="M20 21v-2a4 4 0 0 0-4-4H8a4 4 0 0 0-4 4v2" />
                         <circle cx="12" cy="7" r="4" />
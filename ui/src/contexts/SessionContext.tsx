@@ -0,0 +1,39 @@
+import React, { createContext, useContext, useEffect, useState, ReactNode } from 'react'
+import { getSession, login, logout, onSessionChange, type Session } from '../api/session'
+
+interface SessionContextValue {
+    session: Session | null
+    login: (username: string, password: string) => Promise<void>
+    logout: () => Promise<void>
+}
+
+const SessionContext = createContext<SessionContextValue | undefined>(undefined)
+
+export function SessionProvider({ children }: { children: ReactNode }) {
+    const [session, setSession] = useState<Session | null>(getSession())
+
+    // Follow logins, logouts and sessions the server has ended.
+    useEffect(() => onSessionChange(setSession), [])
+
+    const value: SessionContextValue = {
+        session,
+        login: async (username, password) => {
+            await login(username, password)
+        },
+        logout,
+    }
+
+    return (
+        <SessionContext.Provider value={value}>
+            {children}
+        </SessionContext.Provider>
+    )
+}
+
+export function useSession() {
+    const context = useContext(SessionContext)
+    if (!context) {
+        throw new Error('useSession must be used within a SessionProvider')
+    }
+    return context
+}
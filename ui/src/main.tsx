@@ -1,7 +1,12 @@
 import React from 'react'
 import { createRoot } from 'react-dom/client'
 import App from './App'
+import { SessionProvider } from './contexts/SessionContext'
 import './styles.scss'
 
 const root = createRoot(document.getElementById('root')!)
-root.render(<App />)
+root.render(
+    <SessionProvider>
+        <App />
+    </SessionProvider>
+)